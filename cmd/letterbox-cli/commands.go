@@ -0,0 +1,332 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
+	"github.com/anshonweb/letterbox-cli/internal/poll"
+	"github.com/anshonweb/letterbox-cli/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const usage = `Usage:
+  lettercli                                 open the interactive interface
+  lettercli <letterboxd or boxd.it URL>     open the interface on that page
+  lettercli list [flags] <url|owner/slug>   print the films on a list
+  lettercli watchlist diff [flags] <user>   show films added to or removed from a watchlist
+  lettercli poll [flags] [file]             vote on films from an exported table over the local network
+
+Run 'lettercli <command> -h' for a command's flags.
+`
+
+// usageError is returned for bad arguments, which exit with status 2.
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+var commands = map[string]func(args []string) error{
+	"list":      runList,
+	"poll":      runPoll,
+	"watchlist": runWatchlist,
+}
+
+// runCommand runs the subcommand name and returns the process exit code.
+func runCommand(name string, args []string) int {
+	if name == "-h" || name == "--help" || name == "help" {
+		fmt.Print(usage)
+		return 0
+	}
+	run, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "lettercli: unknown command %q\n\n%s", name, usage)
+		return 2
+	}
+
+	err := run(args)
+	var uErr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &uErr):
+		fmt.Fprintf(os.Stderr, "lettercli %s: %v\n\n%s", name, err, usage)
+		return 2
+	default:
+		fmt.Fprintf(os.Stderr, "lettercli %s: %v\n", name, err)
+		return 1
+	}
+}
+
+func formatNames() string {
+	var names []string
+	for _, e := range export.Formats(export.Table{}) {
+		names = append(names, e.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+// parseInterspersed parses flags that may come before or after the
+// positional arguments, so both "list -format json x/y" and
+// "list x/y -format json" work.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	format := fs.String("format", "csv", "output format: "+formatNames())
+	output := fs.String("o", "", "write to this file instead of standard output; the format follows its extension unless -format is given")
+	force := fs.Bool("force", false, "overwrite the -o file if it exists")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lettercli list [flags] <https://letterboxd.com/<owner>/list/<slug>/ | owner/slug>")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError{"expected exactly one list URL or owner/slug"}
+	}
+	ref, ok := links.ParseList(positional[0])
+	if !ok {
+		return usageError{fmt.Sprintf("%q is not a list URL or owner/slug", positional[0])}
+	}
+
+	formatSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "format" {
+			formatSet = true
+		}
+	})
+	e, ok := export.Lookup(*format)
+	if *output != "" && !formatSet {
+		e, ok = export.ForPath(*output)
+		if !ok {
+			return usageError{fmt.Sprintf("cannot tell the format of %s, pass -format", *output)}
+		}
+	}
+	if !ok {
+		return usageError{fmt.Sprintf("unknown format %q, expected one of: %s", *format, formatNames())}
+	}
+
+	t, err := ui.ListTable(ref.Owner, ref.Slug)
+	if err != nil {
+		return err
+	}
+	if !e.Supports(t) {
+		return usageError{fmt.Sprintf("format %s cannot represent a list", e.Name())}
+	}
+
+	if *output == "" {
+		return e.Export(os.Stdout, t)
+	}
+	path, err := export.ExpandPath(*output)
+	if err != nil {
+		return err
+	}
+	written, err := export.WriteFile(path, e, t, *force)
+	if errors.Is(err, export.ErrExists) {
+		return fmt.Errorf("%s already exists, pass -force to overwrite it", path)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d films to %s\n", len(t.Rows), written)
+	return nil
+}
+
+// dateLayout is how dates are given on the command line.
+const dateLayout = "2006-01-02"
+
+// parseDay parses a date flag as the end of that day in local time, so
+// snapshots taken during the day count as being on or before it.
+func parseDay(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	day, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, usageError{fmt.Sprintf("-%s %q is not a date like %s", name, value, dateLayout)}
+	}
+	return day.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+func runWatchlist(args []string) error {
+	if len(args) == 0 || args[0] != "diff" {
+		return usageError{"expected 'watchlist diff'"}
+	}
+
+	fs := flag.NewFlagSet("watchlist diff", flag.ContinueOnError)
+	fromFlag := fs.String("from", "", "compare from the last snapshot on or before this date ("+dateLayout+"); defaults to the snapshot before -to")
+	toFlag := fs.String("to", "", "compare to the last snapshot on or before this date ("+dateLayout+"); defaults to fetching the watchlist now")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lettercli watchlist diff [flags] <username>")
+		fmt.Fprintln(fs.Output(), "\nEvery time a whole watchlist is fetched a snapshot of it is saved. Without flags this\nfetches it again and shows what changed since the last snapshot.")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError{"expected exactly one username"}
+	}
+	from, err := parseDay("from", *fromFlag)
+	if err != nil {
+		return err
+	}
+	to, err := parseDay("to", *toFlag)
+	if err != nil {
+		return err
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return usageError{"-from must be before -to"}
+	}
+
+	d, err := ui.DiffWatchlist(positional[0], from, to)
+	if err != nil {
+		return err
+	}
+
+	const stamp = "2 Jan 2006 15:04"
+	fmt.Printf("@%s's watchlist, %s → %s\n", d.Username, d.From.Local().Format(stamp), d.To.Local().Format(stamp))
+	for _, m := range d.Added {
+		fmt.Printf("+ %s (%d)\n", m.Title, m.Year)
+	}
+	for _, m := range d.Removed {
+		fmt.Printf("- %s (%d)\n", m.Title, m.Year)
+	}
+	fmt.Printf("%d added, %d removed\n", len(d.Added), len(d.Removed))
+	return nil
+}
+
+// pollFilms takes the films from a table read back from an export, using
+// its Title and, if there is one, Year column.
+func pollFilms(t export.Table, limit int) ([]poll.Film, error) {
+	title, year := -1, -1
+	for i, c := range t.Columns {
+		switch strings.ToLower(c.Name) {
+		case "title":
+			title = i
+		case "year":
+			year = i
+		}
+	}
+	if title < 0 {
+		return nil, errors.New("the table has no Title column")
+	}
+
+	var films []poll.Film
+	for _, row := range t.Rows {
+		if title >= len(row) || strings.TrimSpace(row[title]) == "" {
+			continue
+		}
+		f := poll.Film{Title: strings.TrimSpace(row[title])}
+		if year >= 0 && year < len(row) {
+			f.Year, _ = strconv.Atoi(strings.TrimSpace(row[year]))
+		}
+		films = append(films, f)
+		if len(films) == limit {
+			break
+		}
+	}
+	if len(films) < 2 {
+		return nil, errors.New("a poll needs at least two films")
+	}
+	return films, nil
+}
+
+func runPoll(args []string) error {
+	fs := flag.NewFlagSet("poll", flag.ContinueOnError)
+	format := fs.String("format", "", "format of the input: csv, tsv, json or ndjson; defaults to the file's extension, or csv for standard input")
+	title := fs.String("title", "Movie night", "question shown on the voting page")
+	addr := fs.String("addr", ":8080", "address to serve the voting page on")
+	limit := fs.Int("n", 12, "offer at most this many films, from the top of the table")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lettercli poll [flags] [file | -]")
+		fmt.Fprintln(fs.Output(), "\nReads films from a table exported by lettercli, or from standard input, and serves a\nranked-choice voting page. The result is printed when the poll is stopped.")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return usageError{"expected at most one file"}
+	}
+	if *limit < 2 {
+		return usageError{"-n must be at least 2"}
+	}
+
+	var in io.Reader = os.Stdin
+	name := "csv"
+	fromStdin := len(positional) == 0 || positional[0] == "-"
+	if !fromStdin {
+		path, err := export.ExpandPath(positional[0])
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+		if e, ok := export.ForPath(path); ok {
+			name = e.Name()
+		}
+	}
+	if *format != "" {
+		name = *format
+	}
+
+	t, err := export.Read(in, name)
+	if err != nil {
+		return err
+	}
+	films, err := pollFilms(t, *limit)
+	if err != nil {
+		return err
+	}
+
+	p := poll.New(*title, films)
+	server, err := poll.Serve(p, *addr)
+	if err != nil {
+		return err
+	}
+
+	opts := []tea.ProgramOption{}
+	if fromStdin {
+		// Standard input was the table; read keys from the terminal.
+		opts = append(opts, tea.WithInputTTY())
+	}
+	if _, err := tea.NewProgram(ui.NewPollModel(p, server, nil), opts...).Run(); err != nil {
+		server.Close()
+		return err
+	}
+	fmt.Println(p.Summary())
+	return nil
+}
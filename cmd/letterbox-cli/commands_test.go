@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/poll"
+)
+
+// TestPollFromExport feeds a watchlist export back into the poll the way
+// 'lettercli poll' does.
+func TestPollFromExport(t *testing.T) {
+	watchlist := export.Table{
+		Title: "alice's Watchlist",
+		Columns: []export.Column{
+			{Name: "Title"},
+			{Name: "Year", Numeric: true},
+			{Name: "Director"},
+		},
+		Rows: [][]string{
+			{"Crouching Tiger, Hidden Dragon", "2000", "Ang Lee"},
+			{"", "1999", "Nobody"},
+			{"Year Unknown", "", ""},
+			{`"Quoted" Title`, "1995", "Michael Mann"},
+		},
+	}
+	want := []poll.Film{
+		{Title: "Crouching Tiger, Hidden Dragon", Year: 2000},
+		{Title: "Year Unknown"},
+		{Title: `"Quoted" Title`, Year: 1995},
+	}
+
+	for _, format := range []string{"csv", "tsv", "json", "ndjson"} {
+		t.Run(format, func(t *testing.T) {
+			e, _ := export.Lookup(format)
+			var buf bytes.Buffer
+			if err := e.Export(&buf, watchlist); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			table, err := export.Read(&buf, format)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			films, err := pollFilms(table, 12)
+			if err != nil {
+				t.Fatalf("pollFilms: %v", err)
+			}
+			if !reflect.DeepEqual(films, want) {
+				t.Errorf("pollFilms = %+v, want %+v", films, want)
+			}
+		})
+	}
+}
+
+func TestPollFilms(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		limit   int
+		want    []poll.Film
+		wantErr string
+	}{
+		{
+			name:  "column names in any case",
+			input: "title,YEAR\nHeat,1995\nAlien,1979\n",
+			limit: 12,
+			want:  []poll.Film{{Title: "Heat", Year: 1995}, {Title: "Alien", Year: 1979}},
+		},
+		{
+			name:  "limit keeps the top of the table",
+			input: "Title\nHeat\nAlien\nRan\n",
+			limit: 2,
+			want:  []poll.Film{{Title: "Heat"}, {Title: "Alien"}},
+		},
+		{
+			name:    "no title column",
+			input:   "Name,Year\nHeat,1995\nAlien,1979\n",
+			limit:   12,
+			wantErr: "no Title column",
+		},
+		{
+			name:    "one film",
+			input:   "Title\nHeat\n\n",
+			limit:   12,
+			wantErr: "at least two films",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := export.Read(strings.NewReader(tt.input), "csv")
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			films, err := pollFilms(table, tt.limit)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("pollFilms error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pollFilms: %v", err)
+			}
+			if !reflect.DeepEqual(films, tt.want) {
+				t.Errorf("pollFilms = %+v, want %+v", films, tt.want)
+			}
+		})
+	}
+}
@@ -4,12 +4,21 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/anshonweb/letterbox-cli/internal/links"
 	"github.com/anshonweb/letterbox-cli/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	p := tea.NewProgram(ui.NewRootModel())
+	root := ui.NewRootModel()
+	if len(os.Args) > 1 {
+		if !links.IsLink(os.Args[1]) {
+			os.Exit(runCommand(os.Args[1], os.Args[2:]))
+		}
+		root = ui.NewRootModelForLink(os.Args[1])
+	}
+
+	p := tea.NewProgram(root)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Println("Error running program:", err)
@@ -0,0 +1,52 @@
+// Package atomicfile writes files so that readers only ever see the old
+// contents or the complete new ones, never a partial write.
+package atomicfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Write passes a temporary file in path's directory to write, syncs it to
+// disk and moves it to path. place does the move; nil means os.Rename,
+// which replaces any existing file. The temporary file is removed if
+// anything fails.
+func Write(path string, perm os.FileMode, write func(io.Writer) error, place func(tmpPath, path string) error) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+
+	if place == nil {
+		place = os.Rename
+	}
+	return place(tmpPath, path)
+}
+
+// WriteFile is Write for data already in memory.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	return Write(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}, nil)
+}
@@ -0,0 +1,65 @@
+// Package cache keeps fetched data on disk between runs, under the user's
+// cache directory.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/atomicfile"
+)
+
+// Store is one named cache directory. Entries older than its maximum age
+// are treated as missing.
+type Store struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// Open returns the store called name, creating its directory if needed.
+// A maxAge of zero keeps entries forever.
+func Open(name string, maxAge time.Duration) (*Store, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("could not find cache directory: %w", err)
+	}
+	dir := filepath.Join(base, "lettercli", name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+	return &Store{dir: dir, maxAge: maxAge}, nil
+}
+
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16]))
+}
+
+// Get returns the data stored under key, if it is present and fresh.
+func (s *Store) Get(key string) ([]byte, bool) {
+	path := s.path(key)
+	if s.maxAge > 0 {
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) > s.maxAge {
+			return nil, false
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores data under key. The entry is written to a temporary file and
+// renamed into place so concurrent readers never see a partial entry.
+func (s *Store) Put(key string, data []byte) error {
+	if err := atomicfile.WriteFile(s.path(key), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
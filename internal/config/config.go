@@ -0,0 +1,88 @@
+// Package config loads the user's LetterCLI settings from
+// <user config dir>/lettercli/config.json, for example:
+//
+//	{
+//	    "images": false,
+//	    "username": "alice"
+//	}
+//
+// Missing settings keep their defaults.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Config holds the user's settings.
+type Config struct {
+	// Images turns poster images on film details on or off. The
+	// LETTERCLI_IMAGES environment variable ("0", "false", "1", "true")
+	// overrides it.
+	Images bool `json:"images"`
+
+	// Username is the user's own Letterboxd username, used to show how
+	// the people they follow rated a film. The LETTERCLI_USERNAME
+	// environment variable overrides it.
+	Username string `json:"username"`
+}
+
+// Default returns the settings used when there is no config file.
+func Default() Config {
+	return Config{
+		Images: true,
+	}
+}
+
+// Path returns where the config file is read from.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find config directory: %w", err)
+	}
+	return filepath.Join(dir, "lettercli", "config.json"), nil
+}
+
+// Load reads the config file, if there is one, and applies environment
+// overrides. If the file cannot be read the defaults are used and the
+// error is returned alongside them.
+func Load() (Config, error) {
+	cfg, err := readFile()
+	if err != nil {
+		cfg = Default()
+	}
+
+	if v := os.Getenv("LETTERCLI_IMAGES"); v != "" {
+		if on, parseErr := strconv.ParseBool(v); parseErr == nil {
+			cfg.Images = on
+		}
+	}
+	if v := os.Getenv("LETTERCLI_USERNAME"); v != "" {
+		cfg.Username = v
+	}
+	return cfg, err
+}
+
+func readFile() (Config, error) {
+	cfg := Default()
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// delimitedExporter writes CSV-style files with a configurable separator.
+type delimitedExporter struct {
+	name  string
+	ext   string
+	comma rune
+}
+
+func (e delimitedExporter) Name() string        { return e.name }
+func (e delimitedExporter) Extension() string   { return e.ext }
+func (e delimitedExporter) Supports(Table) bool { return true }
+
+func (e delimitedExporter) Export(w io.Writer, t Table) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = e.comma
+
+	header := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		header[i] = c.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	var errs rowErrorCollector
+	for i, row := range t.Rows {
+		if err := checkRow(t, row); err != nil {
+			errs.add(i, err)
+			continue
+		}
+		if err := writer.Write(row); err != nil {
+			errs.add(i, err)
+		}
+	}
+
+	writer.Flush()
+	return errs.result(writer.Error())
+}
+
+// checkRow reports rows whose shape does not match the table's columns.
+func checkRow(t Table, row []string) error {
+	if len(row) != len(t.Columns) {
+		return fmt.Errorf("has %d cells, expected %d", len(row), len(t.Columns))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Document is a single record, such as a film or a profile, exported as a
+// whole rather than as rows of a table.
+type Document struct {
+	Title    string
+	Sections []Section
+
+	// Data is the underlying value. Formats that carry raw data, such as
+	// JSON, encode it as-is instead of the sections.
+	Data any
+}
+
+// Section is one titled part of a Document. Any of Fields, Text and Items
+// may be empty; they are rendered in that order.
+type Section struct {
+	Heading string
+	Fields  []Field
+	Text    string
+	Items   []string
+}
+
+// Field is a labelled value within a Section.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// DocumentExporter is implemented by formats that can also encode a
+// Document.
+type DocumentExporter interface {
+	Exporter
+	ExportDocument(w io.Writer, d Document) error
+}
+
+// DocumentFormats returns the registered exporters that can write
+// documents, in registration order.
+func DocumentFormats() []Exporter {
+	var out []Exporter
+	for _, e := range registry {
+		if _, ok := e.(DocumentExporter); ok {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// WriteDocumentFile exports d to path with e, following the same rules as
+// WriteFile.
+func WriteDocumentFile(path string, e Exporter, d Document, overwrite bool) (string, error) {
+	de, ok := e.(DocumentExporter)
+	if !ok {
+		return "", fmt.Errorf("%s cannot export documents", e.Name())
+	}
+	return writeAtomic(path, overwrite, func(w io.Writer) error {
+		if err := de.ExportDocument(w, d); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.Name(), err)
+		}
+		return nil
+	})
+}
+
+func (jsonExporter) ExportDocument(w io.Writer, d Document) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if d.Data != nil {
+		return enc.Encode(d.Data)
+	}
+	return enc.Encode(d.Sections)
+}
+
+func (markdownExporter) ExportDocument(w io.Writer, d Document) error {
+	bw := bufio.NewWriter(w)
+
+	if d.Title != "" {
+		bw.WriteString("# " + d.Title + "\n")
+	}
+	for _, s := range d.Sections {
+		if len(s.Fields) == 0 && s.Text == "" && len(s.Items) == 0 {
+			continue
+		}
+		bw.WriteString("\n")
+		if s.Heading != "" {
+			bw.WriteString("## " + s.Heading + "\n\n")
+		}
+		for _, f := range s.Fields {
+			if f.Value == "" {
+				continue
+			}
+			bw.WriteString("- **" + f.Name + ":** " + markdownLine(f.Value) + "\n")
+		}
+		if len(s.Fields) > 0 && (s.Text != "" || len(s.Items) > 0) {
+			bw.WriteString("\n")
+		}
+		if s.Text != "" {
+			bw.WriteString(strings.TrimSpace(s.Text) + "\n")
+			if len(s.Items) > 0 {
+				bw.WriteString("\n")
+			}
+		}
+		for _, item := range s.Items {
+			bw.WriteString("- " + markdownLine(item) + "\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+// markdownLine keeps multi-line values inside a single list item by
+// indenting continuation lines.
+func markdownLine(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n  ")
+}
@@ -0,0 +1,291 @@
+// Package export writes the data shown on LetterCLI screens to files in a
+// range of formats. Screens describe their data as a Table and pick an
+// Exporter from the registry; the exporter takes care of the encoding.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/atomicfile"
+)
+
+// Column describes one column of a Table. Numeric columns are written as
+// numbers by formats that distinguish them (JSON, NDJSON).
+type Column struct {
+	Name    string
+	Numeric bool
+}
+
+// Table is the format-independent shape of exported data.
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    [][]string
+
+	// DateColumn names the column that holds YYYY-MM-DD dates. Formats
+	// that are organised around dates, such as iCalendar, only support
+	// tables that set it.
+	DateColumn string
+}
+
+// ColumnIndex returns the index of the named column, or -1.
+func (t Table) ColumnIndex(name string) int {
+	for i, c := range t.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Exporter encodes a Table in one file format.
+type Exporter interface {
+	// Name is the short identifier shown to the user, e.g. "csv".
+	Name() string
+	// Extension is the file extension including the dot, e.g. ".csv".
+	Extension() string
+	// Supports reports whether the format can represent t.
+	Supports(t Table) bool
+	// Export writes t to w. Rows that cannot be written are skipped and
+	// reported through a RowErrors value once the rest of the table has
+	// been written.
+	Export(w io.Writer, t Table) error
+}
+
+var registry []Exporter
+
+// Register adds e to the set of known formats. Registering a name twice
+// replaces the earlier exporter.
+func Register(e Exporter) {
+	for i, existing := range registry {
+		if existing.Name() == e.Name() {
+			registry[i] = e
+			return
+		}
+	}
+	registry = append(registry, e)
+}
+
+// Lookup returns the exporter registered under name.
+func Lookup(name string) (Exporter, bool) {
+	for _, e := range registry {
+		if e.Name() == name {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// ForPath returns the exporter whose extension matches path.
+func ForPath(path string) (Exporter, bool) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" {
+		return nil, false
+	}
+	for _, e := range registry {
+		if e.Extension() == ext {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Formats returns the registered exporters that support t, in registration
+// order.
+func Formats(t Table) []Exporter {
+	var out []Exporter
+	for _, e := range registry {
+		if e.Supports(t) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// RowError records a row that could not be written.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row+1, e.Err)
+}
+
+func (e RowError) Unwrap() error { return e.Err }
+
+// RowErrors is returned when an export completed but some rows were
+// skipped.
+type RowErrors []RowError
+
+func (e RowErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d rows could not be written (first: %v)", len(e), e[0])
+}
+
+// rowErrorCollector accumulates per-row failures during an export.
+type rowErrorCollector struct {
+	errs RowErrors
+}
+
+func (c *rowErrorCollector) add(row int, err error) {
+	c.errs = append(c.errs, RowError{Row: row, Err: err})
+}
+
+// result combines a fatal error with the collected row errors.
+func (c *rowErrorCollector) result(err error) error {
+	if err != nil {
+		return err
+	}
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+	return nil
+}
+
+// ErrExists is returned by WriteFile when the destination already exists
+// and overwriting was not requested.
+var ErrExists = errors.New("file already exists")
+
+// ExpandPath resolves a user-typed path: it expands a leading "~" to the
+// home directory and makes relative paths absolute.
+func ExpandPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("no path given")
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not expand ~: %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path format: %w", err)
+	}
+	return abs, nil
+}
+
+// Exists reports whether something is already present at path.
+func Exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// NextFreePath returns path with the first "-N" suffix, starting at 1,
+// that does not exist yet, e.g. "diary.csv" becomes "diary-1.csv".
+func NextFreePath(path string) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for n := 1; ; n++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, n, ext)
+		if !Exists(candidate) {
+			return candidate
+		}
+	}
+}
+
+// WriteFile exports t to path with e and returns the absolute path that
+// was written. Unless overwrite is set, an existing file is left untouched
+// and ErrExists is returned. A RowErrors error means the file was written
+// but some rows were left out.
+func WriteFile(path string, e Exporter, t Table, overwrite bool) (string, error) {
+	return writeAtomic(path, overwrite, func(w io.Writer) error {
+		if err := e.Export(w, t); err != nil {
+			var rowErrs RowErrors
+			if errors.As(err, &rowErrs) {
+				return err
+			}
+			return fmt.Errorf("failed to write %s: %w", e.Name(), err)
+		}
+		return nil
+	})
+}
+
+// writeAtomic writes to a temporary file in the destination directory and
+// moves it into place once complete, so a failed export never leaves a
+// truncated file behind.
+func writeAtomic(path string, overwrite bool, write func(io.Writer) error) (string, error) {
+	filePath, err := ExpandPath(path)
+	if err != nil {
+		return "", err
+	}
+	if !overwrite && Exists(filePath) {
+		return filePath, ErrExists
+	}
+
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		if os.IsPermission(err) {
+			return "", fmt.Errorf("permission denied creating directory %s", dir)
+		}
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Skipped rows still leave a usable file, so they are reported only
+	// once it is in place.
+	var rowErrs RowErrors
+	err = atomicfile.Write(filePath, 0o644, func(w io.Writer) error {
+		if err := write(w); err != nil && !errors.As(err, &rowErrs) {
+			return err
+		}
+		return nil
+	}, func(tmpPath, filePath string) error {
+		return commit(tmpPath, filePath, overwrite)
+	})
+	switch {
+	case errors.Is(err, ErrExists):
+		return filePath, err
+	case errors.Is(err, fs.ErrPermission):
+		return "", fmt.Errorf("permission denied writing %s", filePath)
+	case err != nil:
+		return "", err
+	case rowErrs != nil:
+		return filePath, rowErrs
+	}
+	return filePath, nil
+}
+
+// commit moves the finished temporary file to its destination. Without
+// overwrite it links rather than renames, so a file created at the
+// destination in the meantime is never replaced.
+func commit(tmpPath, filePath string, overwrite bool) error {
+	if !overwrite {
+		err := os.Link(tmpPath, filePath)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, os.ErrExist) {
+			return ErrExists
+		}
+		// Some filesystems do not support hard links; fall back to a
+		// rename after checking once more.
+		if Exists(filePath) {
+			return ErrExists
+		}
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("failed to move export into place at %s: %w", filePath, err)
+	}
+	return nil
+}
+
+func init() {
+	Register(delimitedExporter{name: "csv", ext: ".csv", comma: ','})
+	Register(delimitedExporter{name: "tsv", ext: ".tsv", comma: '\t'})
+	Register(jsonExporter{})
+	Register(ndjsonExporter{})
+	Register(markdownExporter{})
+	Register(htmlExporter{})
+	Register(icalExporter{})
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileNoClobber(t *testing.T) {
+	csv, _ := Lookup("csv")
+	first := Table{Columns: []Column{{Name: "Title"}}, Rows: [][]string{{"Heat"}}}
+	second := Table{Columns: []Column{{Name: "Title"}}, Rows: [][]string{{"Alien"}}}
+
+	tests := []struct {
+		name      string
+		overwrite bool
+		wantErr   error
+		want      string
+	}{
+		{"kept without overwrite", false, ErrExists, "Title\nHeat\n"},
+		{"replaced with overwrite", true, nil, "Title\nAlien\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "nested", "watchlist.csv")
+			if _, err := WriteFile(path, csv, first, false); err != nil {
+				t.Fatalf("first WriteFile: %v", err)
+			}
+
+			written, err := WriteFile(path, csv, second, tt.overwrite)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("second WriteFile error = %v, want %v", err, tt.wantErr)
+			}
+			if written != path {
+				t.Errorf("WriteFile returned %q, want %q", written, path)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("file holds %q, want %q", data, tt.want)
+			}
+			assertOnlyFile(t, filepath.Dir(path), "watchlist.csv")
+		})
+	}
+}
+
+func TestWriteFileKeepsWellFormedRows(t *testing.T) {
+	csv, _ := Lookup("csv")
+	table := Table{
+		Columns: []Column{{Name: "Title"}, {Name: "Year"}},
+		Rows:    [][]string{{"Heat", "1995"}, {"Short row"}},
+	}
+	path := filepath.Join(t.TempDir(), "films.csv")
+
+	_, err := WriteFile(path, csv, table, false)
+	var rowErrs RowErrors
+	if !errors.As(err, &rowErrs) || len(rowErrs) != 1 {
+		t.Fatalf("WriteFile error = %v, want one RowError", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("file was not written: %v", err)
+	}
+	if want := "Title,Year\nHeat,1995\n"; string(data) != want {
+		t.Errorf("file holds %q, want %q", data, want)
+	}
+}
+
+func TestNextFreePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "diary.csv")
+	for _, name := range []string{"diary.csv", "diary-1.csv"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := NextFreePath(path), filepath.Join(dir, "diary-2.csv"); got != want {
+		t.Errorf("NextFreePath = %q, want %q", got, want)
+	}
+}
+
+// assertOnlyFile fails unless name is the only entry in dir, which shows
+// no temporary file was left behind.
+func assertOnlyFile(t *testing.T, dir, name string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != name {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("directory holds %q, want only %q", names, name)
+	}
+}
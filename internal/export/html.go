@@ -0,0 +1,68 @@
+package export
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"strings"
+)
+
+// htmlExporter writes a standalone HTML page containing the table.
+type htmlExporter struct{}
+
+func (htmlExporter) Name() string        { return "html" }
+func (htmlExporter) Extension() string   { return ".html" }
+func (htmlExporter) Supports(Table) bool { return true }
+
+const htmlHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>%TITLE%</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.7em; text-align: left; }
+th { background: #00A86B; color: #fff; }
+td.num { text-align: right; }
+</style>
+</head>
+<body>
+`
+
+func (htmlExporter) Export(w io.Writer, t Table) error {
+	bw := bufio.NewWriter(w)
+	var errs rowErrorCollector
+
+	title := t.Title
+	if title == "" {
+		title = "LetterCLI export"
+	}
+	bw.WriteString(strings.Replace(htmlHead, "%TITLE%", html.EscapeString(title), 1))
+
+	bw.WriteString("<h1>" + html.EscapeString(title) + "</h1>\n<table>\n<thead><tr>")
+	for _, c := range t.Columns {
+		bw.WriteString("<th>" + html.EscapeString(c.Name) + "</th>")
+	}
+	bw.WriteString("</tr></thead>\n<tbody>\n")
+
+	for i, row := range t.Rows {
+		if err := checkRow(t, row); err != nil {
+			errs.add(i, err)
+			continue
+		}
+		bw.WriteString("<tr>")
+		for j, cell := range row {
+			if t.Columns[j].Numeric {
+				bw.WriteString(`<td class="num">`)
+			} else {
+				bw.WriteString("<td>")
+			}
+			bw.WriteString(html.EscapeString(cell) + "</td>")
+		}
+		bw.WriteString("</tr>\n")
+	}
+	bw.WriteString("</tbody>\n</table>\n</body>\n</html>\n")
+
+	return errs.result(bw.Flush())
+}
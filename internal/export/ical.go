@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icalExporter writes an iCalendar file with one all-day event per row.
+// It is only offered for tables with a DateColumn, such as the diary.
+type icalExporter struct{}
+
+func (icalExporter) Name() string      { return "ical" }
+func (icalExporter) Extension() string { return ".ics" }
+
+func (icalExporter) Supports(t Table) bool {
+	return t.DateColumn != "" && t.ColumnIndex(t.DateColumn) >= 0
+}
+
+func (e icalExporter) Export(w io.Writer, t Table) error {
+	if !e.Supports(t) {
+		return fmt.Errorf("table has no date column")
+	}
+
+	bw := bufio.NewWriter(w)
+	var errs rowErrorCollector
+
+	dateIdx := t.ColumnIndex(t.DateColumn)
+	titleIdx := t.ColumnIndex("Title")
+	yearIdx := t.ColumnIndex("Year")
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	writeICalLine(bw, "BEGIN:VCALENDAR")
+	writeICalLine(bw, "VERSION:2.0")
+	writeICalLine(bw, "PRODID:-//LetterCLI//Export//EN")
+	writeICalLine(bw, "CALSCALE:GREGORIAN")
+	if t.Title != "" {
+		writeICalLine(bw, "X-WR-CALNAME:"+icalEscape(t.Title))
+	}
+
+	for i, row := range t.Rows {
+		if err := checkRow(t, row); err != nil {
+			errs.add(i, err)
+			continue
+		}
+		day, err := time.Parse("2006-01-02", row[dateIdx])
+		if err != nil {
+			errs.add(i, fmt.Errorf("invalid date %q", row[dateIdx]))
+			continue
+		}
+
+		summary := row[0]
+		if titleIdx >= 0 {
+			summary = row[titleIdx]
+			if yearIdx >= 0 && row[yearIdx] != "" && row[yearIdx] != "0" {
+				summary += " (" + row[yearIdx] + ")"
+			}
+		}
+
+		var details []string
+		for j, c := range t.Columns {
+			if j == dateIdx || j == titleIdx || j == yearIdx || row[j] == "" {
+				continue
+			}
+			details = append(details, c.Name+": "+row[j])
+		}
+
+		sum := sha1.Sum([]byte(strings.Join(row, "\x00")))
+		writeICalLine(bw, "BEGIN:VEVENT")
+		writeICalLine(bw, "UID:"+hex.EncodeToString(sum[:8])+"@lettercli")
+		writeICalLine(bw, "DTSTAMP:"+stamp)
+		writeICalLine(bw, "DTSTART;VALUE=DATE:"+day.Format("20060102"))
+		writeICalLine(bw, "DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format("20060102"))
+		writeICalLine(bw, "SUMMARY:"+icalEscape(summary))
+		if len(details) > 0 {
+			writeICalLine(bw, "DESCRIPTION:"+icalEscape(strings.Join(details, "\n")))
+		}
+		writeICalLine(bw, "END:VEVENT")
+	}
+	writeICalLine(bw, "END:VCALENDAR")
+
+	return errs.result(bw.Flush())
+}
+
+var icalReplacer = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icalEscape(s string) string {
+	return icalReplacer.Replace(s)
+}
+
+// writeICalLine writes a content line, folding it at 75 octets as RFC 5545
+// requires without splitting multi-byte characters.
+func writeICalLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards
+		// the limit.
+		limit = 74
+	}
+	w.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
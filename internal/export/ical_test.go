@@ -0,0 +1,111 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestICalDates(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      string
+		wantStart string
+		wantEnd   string
+	}{
+		{"plain day", "2024-03-15", "DTSTART;VALUE=DATE:20240315", "DTEND;VALUE=DATE:20240316"},
+		{"leap day", "2024-02-29", "DTSTART;VALUE=DATE:20240229", "DTEND;VALUE=DATE:20240301"},
+		{"end of year", "2023-12-31", "DTSTART;VALUE=DATE:20231231", "DTEND;VALUE=DATE:20240101"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := Table{
+				Columns:    []Column{{Name: "Date"}, {Name: "Title"}, {Name: "Year"}},
+				Rows:       [][]string{{tt.date, "Heat", "1995"}},
+				DateColumn: "Date",
+			}
+			var buf bytes.Buffer
+			if err := (icalExporter{}).Export(&buf, table); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			lines := strings.Split(buf.String(), "\r\n")
+			for _, want := range []string{tt.wantStart, tt.wantEnd, "SUMMARY:Heat (1995)"} {
+				if !contains(lines, want) {
+					t.Errorf("missing line %q in:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestICalSkipsBadDates(t *testing.T) {
+	table := Table{
+		Columns:    []Column{{Name: "Date"}, {Name: "Title"}},
+		Rows:       [][]string{{"2023-02-30", "Heat"}, {"15/03/2024", "Alien"}, {"2024-03-15", "Ran"}},
+		DateColumn: "Date",
+	}
+	var buf bytes.Buffer
+	err := (icalExporter{}).Export(&buf, table)
+
+	var rowErrs RowErrors
+	if !errors.As(err, &rowErrs) || len(rowErrs) != 2 || rowErrs[0].Row != 0 || rowErrs[1].Row != 1 {
+		t.Fatalf("Export error = %v, want RowErrors for rows 0 and 1", err)
+	}
+	if n := strings.Count(buf.String(), "BEGIN:VEVENT"); n != 1 {
+		t.Errorf("wrote %d events, want 1", n)
+	}
+}
+
+func TestICalEscapingAndFolding(t *testing.T) {
+	title := strings.Repeat("é", 60) + "; with, commas"
+	table := Table{
+		Columns:    []Column{{Name: "Date"}, {Name: "Title"}, {Name: "Review"}},
+		Rows:       [][]string{{"2024-03-15", title, "line one\nline two"}},
+		DateColumn: "Date",
+	}
+	var buf bytes.Buffer
+	if err := (icalExporter{}).Export(&buf, table); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	var unfolded []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line is %d octets, longer than 75: %q", len(line), line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded[len(unfolded)-1] += line[1:]
+			continue
+		}
+		unfolded = append(unfolded, line)
+	}
+	for _, want := range []string{
+		"SUMMARY:" + strings.Repeat("é", 60) + `\; with\, commas`,
+		`DESCRIPTION:Review: line one\nline two`,
+	} {
+		if !contains(unfolded, want) {
+			t.Errorf("missing line %q in:\n%s", want, strings.Join(unfolded, "\n"))
+		}
+	}
+}
+
+func TestICalNeedsDateColumn(t *testing.T) {
+	table := Table{Columns: []Column{{Name: "Title"}}}
+	if (icalExporter{}).Supports(table) {
+		t.Error("Supports = true for a table without a DateColumn")
+	}
+	table.DateColumn = "Date"
+	if (icalExporter{}).Supports(table) {
+		t.Error("Supports = true when DateColumn names a missing column")
+	}
+}
+
+func contains(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
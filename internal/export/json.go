@@ -0,0 +1,121 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// jsonNumberPattern is the number grammar from RFC 8259. strconv accepts
+// more than this (NaN, Inf, hex floats, a leading '+'), none of which are
+// valid JSON.
+var jsonNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// jsonExporter writes the table as an array of objects keyed by column
+// name, keeping the column order of the table.
+type jsonExporter struct{}
+
+func (jsonExporter) Name() string        { return "json" }
+func (jsonExporter) Extension() string   { return ".json" }
+func (jsonExporter) Supports(Table) bool { return true }
+
+func (jsonExporter) Export(w io.Writer, t Table) error {
+	bw := bufio.NewWriter(w)
+	var errs rowErrorCollector
+
+	bw.WriteString("[")
+	first := true
+	for i, row := range t.Rows {
+		obj, err := encodeRow(t, row)
+		if err != nil {
+			errs.add(i, err)
+			continue
+		}
+		if !first {
+			bw.WriteString(",")
+		}
+		first = false
+		bw.WriteString("\n  ")
+		bw.Write(obj)
+	}
+	if !first {
+		bw.WriteString("\n")
+	}
+	bw.WriteString("]\n")
+
+	return errs.result(bw.Flush())
+}
+
+// ndjsonExporter writes one JSON object per line.
+type ndjsonExporter struct{}
+
+func (ndjsonExporter) Name() string        { return "ndjson" }
+func (ndjsonExporter) Extension() string   { return ".ndjson" }
+func (ndjsonExporter) Supports(Table) bool { return true }
+
+func (ndjsonExporter) Export(w io.Writer, t Table) error {
+	bw := bufio.NewWriter(w)
+	var errs rowErrorCollector
+
+	for i, row := range t.Rows {
+		obj, err := encodeRow(t, row)
+		if err != nil {
+			errs.add(i, err)
+			continue
+		}
+		bw.Write(obj)
+		bw.WriteString("\n")
+	}
+
+	return errs.result(bw.Flush())
+}
+
+// encodeRow renders row as a JSON object with keys in column order.
+// Numeric columns are emitted as numbers; empty numeric cells become null
+// and cells that are not finite JSON numbers are written as strings.
+func encodeRow(t Table, row []string) ([]byte, error) {
+	if err := checkRow(t, row); err != nil {
+		return nil, err
+	}
+
+	out := []byte{'{'}
+	for i, c := range t.Columns {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		key, err := json.Marshal(c.Name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, key...)
+		out = append(out, ':')
+
+		cell := row[i]
+		switch {
+		case c.Numeric && cell == "":
+			out = append(out, "null"...)
+		case c.Numeric && isJSONNumber(cell):
+			out = append(out, cell...)
+		default:
+			value, err := json.Marshal(cell)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, value...)
+		}
+	}
+	return append(out, '}'), nil
+}
+
+// isJSONNumber reports whether cell can be written as a bare JSON number
+// that decoders will read back as a finite value.
+func isJSONNumber(cell string) bool {
+	if !jsonNumberPattern.MatchString(cell) {
+		return false
+	}
+	f, err := strconv.ParseFloat(cell, 64)
+	return err == nil && !math.IsInf(f, 0)
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEncodeRowNumbers(t *testing.T) {
+	table := Table{Columns: []Column{{Name: "Rating", Numeric: true}, {Name: "Title"}}}
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"4.5", `{"Rating":4.5,"Title":"x"}`},
+		{"0", `{"Rating":0,"Title":"x"}`},
+		{"-12", `{"Rating":-12,"Title":"x"}`},
+		{"1e3", `{"Rating":1e3,"Title":"x"}`},
+		{"", `{"Rating":null,"Title":"x"}`},
+		// Numbers strconv accepts but JSON does not, and numbers too
+		// large to decode, are kept as strings.
+		{"NaN", `{"Rating":"NaN","Title":"x"}`},
+		{"Inf", `{"Rating":"Inf","Title":"x"}`},
+		{"+1", `{"Rating":"+1","Title":"x"}`},
+		{"0x10", `{"Rating":"0x10","Title":"x"}`},
+		{"007", `{"Rating":"007","Title":"x"}`},
+		{".5", `{"Rating":".5","Title":"x"}`},
+		{"1e999", `{"Rating":"1e999","Title":"x"}`},
+		{"n/a", `{"Rating":"n/a","Title":"x"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			got, err := encodeRow(table, []string{tt.cell, "x"})
+			if err != nil {
+				t.Fatalf("encodeRow: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("encodeRow = %s, want %s", got, tt.want)
+			}
+			if !json.Valid(got) {
+				t.Errorf("encodeRow wrote invalid JSON %s", got)
+			}
+		})
+	}
+}
+
+func TestJSONSkipsMalformedRows(t *testing.T) {
+	table := Table{
+		Columns: []Column{{Name: "Title"}, {Name: "Year", Numeric: true}},
+		Rows:    [][]string{{"Heat", "1995"}, {"Short row"}, {"Alien", "1979"}},
+	}
+	for _, format := range []string{"json", "ndjson"} {
+		t.Run(format, func(t *testing.T) {
+			e, _ := Lookup(format)
+			var buf bytes.Buffer
+			err := e.Export(&buf, table)
+
+			var rowErrs RowErrors
+			if !errors.As(err, &rowErrs) || len(rowErrs) != 1 || rowErrs[0].Row != 1 {
+				t.Fatalf("Export error = %v, want one RowError for row 1", err)
+			}
+			got, err := Read(&buf, format)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if len(got.Rows) != 2 {
+				t.Errorf("read %d rows, want the 2 well-formed ones", len(got.Rows))
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// markdownExporter writes a GitHub-flavoured Markdown table.
+type markdownExporter struct{}
+
+func (markdownExporter) Name() string        { return "markdown" }
+func (markdownExporter) Extension() string   { return ".md" }
+func (markdownExporter) Supports(Table) bool { return true }
+
+func (markdownExporter) Export(w io.Writer, t Table) error {
+	bw := bufio.NewWriter(w)
+	var errs rowErrorCollector
+
+	if t.Title != "" {
+		bw.WriteString("# " + markdownCell(t.Title) + "\n\n")
+	}
+
+	header := make([]string, len(t.Columns))
+	rule := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		header[i] = markdownCell(c.Name)
+		rule[i] = "---"
+		if c.Numeric {
+			rule[i] = "--:"
+		}
+	}
+	writeMarkdownRow(bw, header)
+	writeMarkdownRow(bw, rule)
+
+	for i, row := range t.Rows {
+		if err := checkRow(t, row); err != nil {
+			errs.add(i, err)
+			continue
+		}
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = markdownCell(cell)
+		}
+		writeMarkdownRow(bw, cells)
+	}
+
+	return errs.result(bw.Flush())
+}
+
+func writeMarkdownRow(w *bufio.Writer, cells []string) {
+	w.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+// markdownCell escapes text so it stays inside a single table cell.
+func markdownCell(s string) string {
+	return markdownReplacer.Replace(s)
+}
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Read parses a table previously written in the named format, so exports
+// can be fed back into commands. Only the row-oriented formats (csv, tsv,
+// json and ndjson) can be read.
+func Read(r io.Reader, format string) (Table, error) {
+	switch format {
+	case "csv":
+		return readDelimited(r, ',')
+	case "tsv":
+		return readDelimited(r, '\t')
+	case "json":
+		var objects []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&objects); err != nil {
+			return Table{}, fmt.Errorf("invalid JSON export: %w", err)
+		}
+		return tableFromObjects(objects)
+	case "ndjson":
+		var objects []json.RawMessage
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+				objects = append(objects, json.RawMessage(bytes.Clone(line)))
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return Table{}, err
+		}
+		return tableFromObjects(objects)
+	default:
+		return Table{}, fmt.Errorf("cannot read %s files; use csv, tsv, json or ndjson", format)
+	}
+}
+
+func readDelimited(r io.Reader, comma rune) (Table, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	records, err := reader.ReadAll()
+	if err != nil {
+		return Table{}, fmt.Errorf("invalid export: %w", err)
+	}
+	if len(records) == 0 {
+		return Table{}, fmt.Errorf("export is empty")
+	}
+	var t Table
+	for _, name := range records[0] {
+		t.Columns = append(t.Columns, Column{Name: name})
+	}
+	t.Rows = records[1:]
+	return t, nil
+}
+
+// tableFromObjects turns JSON objects into rows, taking the columns from
+// the keys of the first object in the order they appear.
+func tableFromObjects(objects []json.RawMessage) (Table, error) {
+	var t Table
+	if len(objects) == 0 {
+		return t, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(objects[0]))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return t, fmt.Errorf("invalid JSON export: expected objects")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return t, fmt.Errorf("invalid JSON export: %w", err)
+		}
+		t.Columns = append(t.Columns, Column{Name: tok.(string)})
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return t, fmt.Errorf("invalid JSON export: %w", err)
+		}
+	}
+
+	for i, obj := range objects {
+		var values map[string]any
+		dec := json.NewDecoder(bytes.NewReader(obj))
+		dec.UseNumber()
+		if err := dec.Decode(&values); err != nil {
+			return t, fmt.Errorf("invalid JSON export, row %d: %w", i+1, err)
+		}
+		row := make([]string, len(t.Columns))
+		for j, c := range t.Columns {
+			switch v := values[c.Name].(type) {
+			case nil:
+			case string:
+				row[j] = v
+			default:
+				row[j] = fmt.Sprint(v)
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// awkwardTable has cells that each format has to escape.
+var awkwardTable = Table{
+	Title: "Watchlist",
+	Columns: []Column{
+		{Name: "Title"},
+		{Name: "Year", Numeric: true},
+		{Name: "Notes"},
+	},
+	Rows: [][]string{
+		{"Crouching Tiger, Hidden Dragon", "2000", `She said "again"`},
+		{"Tab\tSeparated", "", "two\nlines"},
+		{"Amélie", "2001", "  leading and trailing spaces  "},
+		{"", "1999", `back\slash, "quoted", and a ; too`},
+	},
+}
+
+func TestReadRoundTrip(t *testing.T) {
+	for _, format := range []string{"csv", "tsv", "json", "ndjson"} {
+		t.Run(format, func(t *testing.T) {
+			e, ok := Lookup(format)
+			if !ok {
+				t.Fatalf("no %s exporter", format)
+			}
+			var buf bytes.Buffer
+			if err := e.Export(&buf, awkwardTable); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			got, err := Read(&buf, format)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+
+			var names []string
+			for _, c := range got.Columns {
+				names = append(names, c.Name)
+			}
+			if want := []string{"Title", "Year", "Notes"}; !reflect.DeepEqual(names, want) {
+				t.Errorf("columns = %q, want %q", names, want)
+			}
+			if !reflect.DeepEqual(got.Rows, awkwardTable.Rows) {
+				t.Errorf("rows = %q, want %q", got.Rows, awkwardTable.Rows)
+			}
+		})
+	}
+}
+
+func TestReadNumbersBackAsWritten(t *testing.T) {
+	table := Table{
+		Columns: []Column{{Name: "Title"}, {Name: "Rating", Numeric: true}},
+		Rows:    [][]string{{"Heat", "4.5"}, {"Alien", "1e3"}, {"Unrated", ""}, {"Odd", "NaN"}},
+	}
+	for _, format := range []string{"json", "ndjson"} {
+		t.Run(format, func(t *testing.T) {
+			e, _ := Lookup(format)
+			var buf bytes.Buffer
+			if err := e.Export(&buf, table); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			got, err := Read(&buf, format)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if !reflect.DeepEqual(got.Rows, table.Rows) {
+				t.Errorf("rows = %q, want %q", got.Rows, table.Rows)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+		want   string
+	}{
+		{"unreadable format", "markdown", "| Title |", "cannot read markdown"},
+		{"empty csv", "csv", "", "export is empty"},
+		{"unbalanced quote", "csv", "Title\n\"Heat\n", "invalid export"},
+		{"json that is not an array", "json", `{"Title": "Heat"}`, "invalid JSON export"},
+		{"json array of strings", "json", `["Heat"]`, "expected objects"},
+		{"ndjson with a broken line", "ndjson", "{\"Title\": \"Heat\"}\n{\"Title\": \n", "row 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input), tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Read error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEmptyJSON(t *testing.T) {
+	got, err := Read(strings.NewReader("[]\n"), "json")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got.Columns) != 0 || len(got.Rows) != 0 {
+		t.Errorf("Read = %+v, want an empty table", got)
+	}
+}
@@ -0,0 +1,243 @@
+// Package links understands references to Letterboxd pages typed or
+// pasted by the user: full letterboxd.com URLs, boxd.it short links and
+// bare list references such as "owner/slug".
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Kind is the sort of page a link points to.
+type Kind int
+
+const (
+	KindFilm Kind = iota + 1
+	KindUser
+	KindDiary
+	KindWatchlist
+	KindReview
+	KindList
+	KindPerson
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindFilm:
+		return "film"
+	case KindUser:
+		return "profile"
+	case KindDiary:
+		return "diary"
+	case KindWatchlist:
+		return "watchlist"
+	case KindReview:
+		return "review"
+	case KindList:
+		return "list"
+	case KindPerson:
+		return "person"
+	}
+	return "page"
+}
+
+// Target is the page a link points to.
+type Target struct {
+	Kind Kind
+	// Username is the member the page belongs to. It is empty for films
+	// and people.
+	Username string
+	// Slug is the film's slug for films and reviews, the list's slug for
+	// lists and the person's slug for people.
+	Slug string
+	// Role is the filmography page a person link points to, e.g. "actor".
+	Role string
+}
+
+// ListRef identifies a list by its owner's username and its slug.
+type ListRef struct {
+	Owner string
+	Slug  string
+}
+
+func (r ListRef) String() string {
+	return r.Owner + "/" + r.Slug
+}
+
+// URL returns the list's page on Letterboxd.
+func (r ListRef) URL() string {
+	return fmt.Sprintf("https://letterboxd.com/%s/list/%s/", r.Owner, r.Slug)
+}
+
+// personRoles are the first path segments of filmography pages.
+var personRoles = map[string]bool{
+	"actor": true, "director": true, "co-director": true, "producer": true,
+	"executive-producer": true, "writer": true, "original-writer": true,
+	"story": true, "casting": true, "editor": true, "cinematography": true,
+	"assistant-director": true, "additional-directing": true,
+	"camera-operator": true, "lighting": true, "production-design": true,
+	"art-direction": true, "set-decoration": true, "visual-effects": true,
+	"special-effects": true, "stunts": true, "choreography": true,
+	"composer": true, "songs": true, "sound": true, "costume-design": true,
+	"makeup": true, "hairstyling": true,
+}
+
+// reservedPaths are first path segments that are site pages rather than
+// usernames.
+var reservedPaths = map[string]bool{
+	"films": true, "lists": true, "members": true, "journal": true,
+	"search": true, "settings": true, "about": true, "pro": true,
+	"activity": true, "reviews": true, "tags": true, "studio": true,
+	"sign-in": true, "create-account": true, "welcome": true, "legal": true,
+	"showdown": true, "year-in-review": true, "contact": true, "apps": true,
+}
+
+// IsLink reports whether s looks like a Letterboxd or boxd.it URL, as
+// opposed to a search query or username.
+func IsLink(s string) bool {
+	u, ok := parseLink(s)
+	if !ok {
+		return false
+	}
+	host := hostname(u)
+	return host == "letterboxd.com" || host == shortHost
+}
+
+func parseLink(s string) (*url.URL, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, " \t") {
+		return nil, false
+	}
+	lower := strings.ToLower(s)
+	if !strings.Contains(lower, "://") {
+		if !strings.HasPrefix(lower, "letterboxd.com/") && !strings.HasPrefix(lower, "www.letterboxd.com/") &&
+			!strings.HasPrefix(lower, shortHost+"/") {
+			return nil, false
+		}
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, false
+	}
+	return u, true
+}
+
+func hostname(u *url.URL) string {
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// ParseURL works out which page a letterboxd.com URL points to. Short
+// links need a network round trip and are handled by Resolver instead.
+func ParseURL(s string) (Target, error) {
+	u, ok := parseLink(s)
+	if !ok || hostname(u) != "letterboxd.com" {
+		return Target{}, fmt.Errorf("%q is not a Letterboxd URL", s)
+	}
+	return parsePath(u.Path, s)
+}
+
+func parsePath(path, link string) (Target, error) {
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	unsupported := fmt.Errorf("%s is not a film, profile, diary, review, list or person page", link)
+	if len(parts) == 0 {
+		return Target{}, unsupported
+	}
+
+	first := strings.ToLower(parts[0])
+	switch {
+	case first == "film":
+		if len(parts) < 2 || !validName(parts[1]) {
+			return Target{}, unsupported
+		}
+		return Target{Kind: KindFilm, Slug: parts[1]}, nil
+
+	case personRoles[first]:
+		if len(parts) < 2 || !validName(parts[1]) {
+			return Target{}, unsupported
+		}
+		return Target{Kind: KindPerson, Slug: parts[1], Role: first}, nil
+
+	case reservedPaths[first] || !validName(parts[0]):
+		return Target{}, unsupported
+	}
+
+	t := Target{Kind: KindUser, Username: parts[0]}
+	if len(parts) == 1 {
+		return t, nil
+	}
+	switch strings.ToLower(parts[1]) {
+	case "film":
+		if len(parts) < 3 || !validName(parts[2]) {
+			return Target{}, unsupported
+		}
+		t.Kind, t.Slug = KindReview, parts[2]
+	case "list":
+		if len(parts) < 3 || !validName(parts[2]) {
+			return Target{}, unsupported
+		}
+		t.Kind, t.Slug = KindList, strings.ToLower(parts[2])
+	case "films":
+		if len(parts) > 2 && strings.ToLower(parts[2]) == "diary" {
+			t.Kind = KindDiary
+		}
+	case "diary":
+		t.Kind = KindDiary
+	case "watchlist":
+		t.Kind = KindWatchlist
+	}
+	return t, nil
+}
+
+// ParseList accepts a list URL such as
+// https://letterboxd.com/<owner>/list/<slug>/ (with or without the scheme,
+// "www." or any trailing path like "page/2/"), or a bare "owner/slug". It
+// reports false for anything else, including plain search queries.
+func ParseList(s string) (ListRef, bool) {
+	s = strings.TrimSpace(s)
+	if _, ok := parseLink(s); ok {
+		t, err := ParseURL(s)
+		if err != nil || t.Kind != KindList {
+			return ListRef{}, false
+		}
+		return ListRef{Owner: t.Username, Slug: t.Slug}, true
+	}
+
+	parts := strings.Split(strings.Trim(s, "/"), "/")
+	switch {
+	case len(parts) == 2:
+		return newListRef(parts[0], parts[1])
+	case len(parts) == 3 && parts[1] == "list":
+		return newListRef(parts[0], parts[2])
+	}
+	return ListRef{}, false
+}
+
+func newListRef(owner, slug string) (ListRef, bool) {
+	if !validName(owner) || !validName(slug) {
+		return ListRef{}, false
+	}
+	return ListRef{Owner: owner, Slug: strings.ToLower(slug)}, true
+}
+
+// validName reports whether s could be a username or a slug, which only
+// use letters, digits, '-' and '_'.
+func validName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package links
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	shortHost = "boxd.it"
+
+	// maxRedirects bounds how many hops a short link may take before it
+	// reaches a letterboxd.com page.
+	maxRedirects = 5
+)
+
+// Resolver turns links, including boxd.it short links, into Targets.
+type Resolver struct {
+	// Client makes the requests for short links. Its redirect policy is
+	// replaced so each hop can be inspected; everything else, such as the
+	// Transport, is used as is. A nil Client uses http.DefaultTransport
+	// with a 10 second timeout.
+	Client *http.Client
+}
+
+func (r Resolver) client() *http.Client {
+	c := http.Client{Timeout: 10 * time.Second}
+	if r.Client != nil {
+		c = *r.Client
+	}
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &c
+}
+
+// Resolve works out which page link points to. letterboxd.com URLs are
+// parsed directly; boxd.it links are followed, without downloading the
+// page they lead to, until they reach a letterboxd.com URL.
+func (r Resolver) Resolve(ctx context.Context, link string) (Target, error) {
+	u, ok := parseLink(link)
+	if !ok {
+		return Target{}, fmt.Errorf("%q is not a Letterboxd URL", link)
+	}
+
+	client := r.client()
+	for hop := 0; ; hop++ {
+		switch hostname(u) {
+		case "letterboxd.com":
+			return parsePath(u.Path, link)
+		case shortHost:
+		default:
+			return Target{}, fmt.Errorf("%s does not lead to Letterboxd", u)
+		}
+		if hop == maxRedirects {
+			return Target{}, fmt.Errorf("%s redirected too many times", link)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return Target{}, err
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		resp, err := client.Do(req)
+		if err != nil {
+			return Target{}, fmt.Errorf("could not resolve %s: %w", link, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusNotFound {
+			return Target{}, fmt.Errorf("%s does not exist", link)
+		}
+		location := resp.Header.Get("Location")
+		if location == "" {
+			return Target{}, fmt.Errorf("%s did not redirect (%s)", link, resp.Status)
+		}
+		next, err := u.Parse(location)
+		if err != nil {
+			return Target{}, fmt.Errorf("%s redirected to an invalid URL: %w", link, err)
+		}
+		u = next
+	}
+}
@@ -0,0 +1,128 @@
+package links
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// shortLinks maps boxd.it paths to where they redirect. Paths not in the
+// map get a 404.
+var shortLinks = map[string]string{
+	"/film":      "https://letterboxd.com/film/parasite-2019/",
+	"/review":    "https://letterboxd.com/alice/film/parasite-2019/",
+	"/list":      "https://letterboxd.com/alice/list/Best-Of/",
+	"/hop":       "/film",
+	"/loop-a":    "https://boxd.it/loop-b",
+	"/loop-b":    "https://boxd.it/loop-a",
+	"/elsewhere": "https://example.com/film/parasite-2019/",
+}
+
+// newTestResolver returns a Resolver whose boxd.it requests are answered
+// by a local server, along with the paths it was asked for.
+func newTestResolver(t *testing.T) (Resolver, *[]string) {
+	t.Helper()
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		location, ok := shortLinks[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+	}))
+	t.Cleanup(srv.Close)
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := srv.Client()
+	transport := client.Transport
+	client.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Hostname() != shortHost {
+			t.Errorf("request left boxd.it: %s", req.URL)
+			return nil, http.ErrNotSupported
+		}
+		req = req.Clone(req.Context())
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+		return transport.RoundTrip(req)
+	})
+	return Resolver{Client: client}, &requested
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestResolveShortLinks(t *testing.T) {
+	tests := []struct {
+		link string
+		want Target
+	}{
+		{"https://boxd.it/film", Target{Kind: KindFilm, Slug: "parasite-2019"}},
+		{"boxd.it/review", Target{Kind: KindReview, Username: "alice", Slug: "parasite-2019"}},
+		{"https://boxd.it/list", Target{Kind: KindList, Username: "alice", Slug: "best-of"}},
+		{"https://boxd.it/hop", Target{Kind: KindFilm, Slug: "parasite-2019"}},
+	}
+	for _, tt := range tests {
+		r, _ := newTestResolver(t)
+		got, err := r.Resolve(context.Background(), tt.link)
+		if err != nil {
+			t.Errorf("Resolve(%q) failed: %v", tt.link, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %+v, want %+v", tt.link, got, tt.want)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []struct {
+		link    string
+		wantErr string
+	}{
+		{"https://boxd.it/loop-a", "redirected too many times"},
+		{"https://boxd.it/elsewhere", "does not lead to Letterboxd"},
+		{"https://boxd.it/missing", "does not exist"},
+		{"https://example.com/film/parasite-2019/", "does not lead to Letterboxd"},
+		{"parasite", "is not a Letterboxd URL"},
+	}
+	for _, tt := range tests {
+		r, _ := newTestResolver(t)
+		_, err := r.Resolve(context.Background(), tt.link)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Resolve(%q) error = %v, want one containing %q", tt.link, err, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveHopCap(t *testing.T) {
+	r, requested := newTestResolver(t)
+	if _, err := r.Resolve(context.Background(), "https://boxd.it/loop-a"); err == nil {
+		t.Fatal("Resolve of a redirect loop succeeded")
+	}
+	if len(*requested) != maxRedirects {
+		t.Errorf("made %d requests for a redirect loop, want %d", len(*requested), maxRedirects)
+	}
+}
+
+func TestResolveDirectURL(t *testing.T) {
+	r, requested := newTestResolver(t)
+	got, err := r.Resolve(context.Background(), "https://letterboxd.com/alice/film/parasite-2019/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Target{Kind: KindReview, Username: "alice", Slug: "parasite-2019"}); got != want {
+		t.Errorf("Resolve = %+v, want %+v", got, want)
+	}
+	if len(*requested) != 0 {
+		t.Errorf("letterboxd.com URL made %d requests, want none", len(*requested))
+	}
+}
@@ -0,0 +1,330 @@
+// Package poll runs a ranked-choice vote over a handful of films.
+// Ballots come in through a small web page served on the local network,
+// so everyone at a movie night can vote from their phone.
+package poll
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrClosed is returned for ballots cast after voting has closed.
+var ErrClosed = errors.New("voting has closed")
+
+// Film is one option on the ballot.
+type Film struct {
+	Title string `json:"title"`
+	Year  int    `json:"year,omitempty"`
+}
+
+func (f Film) String() string {
+	if f.Year > 0 {
+		return fmt.Sprintf("%s (%d)", f.Title, f.Year)
+	}
+	return f.Title
+}
+
+// Ballot is one voter's ranking, as indexes into the poll's films, most
+// preferred first. Films left out are ranked below all the others.
+type Ballot struct {
+	Voter   string `json:"voter"`
+	Ranking []int  `json:"ranking"`
+}
+
+// Poll collects ballots. It is safe for concurrent use.
+type Poll struct {
+	Title string
+	Films []Film
+
+	mu      sync.Mutex
+	ballots map[string]Ballot
+	order   []string
+	closed  bool
+	changed chan struct{}
+}
+
+func New(title string, films []Film) *Poll {
+	return &Poll{
+		Title:   title,
+		Films:   films,
+		ballots: map[string]Ballot{},
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Vote records voter's ranking, replacing any ballot they cast before.
+// Voter names are compared case-insensitively.
+func (p *Poll) Vote(voter string, ranking []int) error {
+	voter = strings.TrimSpace(voter)
+	if voter == "" {
+		return errors.New("a name is needed to vote")
+	}
+	if len(ranking) == 0 {
+		return errors.New("rank at least one film")
+	}
+	seen := make(map[int]bool, len(ranking))
+	for _, f := range ranking {
+		if f < 0 || f >= len(p.Films) {
+			return fmt.Errorf("there is no film %d", f+1)
+		}
+		if seen[f] {
+			return fmt.Errorf("%s is ranked more than once", p.Films[f].Title)
+		}
+		seen[f] = true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrClosed
+	}
+	key := strings.ToLower(voter)
+	if _, ok := p.ballots[key]; !ok {
+		p.order = append(p.order, key)
+	}
+	p.ballots[key] = Ballot{Voter: voter, Ranking: append([]int(nil), ranking...)}
+	p.notify()
+	return nil
+}
+
+// Close stops accepting ballots.
+func (p *Poll) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	p.notify()
+}
+
+// Closed reports whether voting has closed.
+func (p *Poll) Closed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// notify wakes a Changed receiver without ever blocking; one pending
+// signal is enough since receivers reread the whole poll.
+func (p *Poll) notify() {
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Changed receives a value after ballots are cast or voting closes.
+func (p *Poll) Changed() <-chan struct{} {
+	return p.changed
+}
+
+// Ballots returns the ballots in the order voters first cast them.
+func (p *Poll) Ballots() []Ballot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ballots := make([]Ballot, len(p.order))
+	for i, key := range p.order {
+		ballots[i] = p.ballots[key]
+	}
+	return ballots
+}
+
+// Tally counts each film's first preferences.
+func (p *Poll) Tally() []int {
+	counts := make([]int, len(p.Films))
+	for _, b := range p.Ballots() {
+		counts[b.Ranking[0]]++
+	}
+	return counts
+}
+
+// Round is one count of an instant-runoff vote.
+type Round struct {
+	// Counts holds each film's votes this round, or -1 for films
+	// eliminated in an earlier round.
+	Counts []int `json:"counts"`
+	// Eliminated lists the films knocked out after this round.
+	Eliminated []int `json:"eliminated,omitempty"`
+	// Exhausted counts ballots with no films left on them.
+	Exhausted int `json:"exhausted"`
+}
+
+// Result is the outcome of an instant-runoff count.
+type Result struct {
+	Rounds []Round `json:"rounds"`
+	// Winner is the winning film, or -1 if there were no ballots or the
+	// last films standing tied.
+	Winner int `json:"winner"`
+	// Tied lists the films that tied for the win.
+	Tied []int `json:"tied,omitempty"`
+}
+
+// Result counts the ballots by instant runoff: each round every ballot
+// counts for its highest-ranked film still standing, and the film with
+// the fewest votes is knocked out, until one film has a majority of the
+// ballots still in play. A tie for fewest votes knocks out whichever film
+// is ranked lowest across all ballots; films still level go out together,
+// and if that would knock out every film left, they share the win.
+func (p *Poll) Result() Result {
+	return count(len(p.Films), p.Ballots())
+}
+
+func count(films int, ballots []Ballot) Result {
+	result := Result{Winner: -1}
+	if len(ballots) == 0 || films == 0 {
+		return result
+	}
+
+	// Borda scores break ties for last place: a film ranked k-th on a
+	// ballot scores films-k.
+	scores := make([]int, films)
+	for _, b := range ballots {
+		for k, f := range b.Ranking {
+			scores[f] += films - k
+		}
+	}
+
+	standing := make([]bool, films)
+	for i := range standing {
+		standing[i] = true
+	}
+	for {
+		round := Round{Counts: make([]int, films)}
+		for f := range round.Counts {
+			if !standing[f] {
+				round.Counts[f] = -1
+			}
+		}
+		live := 0
+		for _, b := range ballots {
+			top := -1
+			for _, f := range b.Ranking {
+				if standing[f] {
+					top = f
+					break
+				}
+			}
+			if top < 0 {
+				round.Exhausted++
+				continue
+			}
+			round.Counts[top]++
+			live++
+		}
+
+		fewest, most, leader, left := -1, -1, -1, 0
+		for f, c := range round.Counts {
+			if c < 0 {
+				continue
+			}
+			left++
+			if fewest < 0 || c < fewest {
+				fewest = c
+			}
+			if c > most {
+				most, leader = c, f
+			}
+		}
+
+		if left == 1 || 2*most > live {
+			result.Rounds = append(result.Rounds, round)
+			result.Winner = leader
+			return result
+		}
+
+		var out []int
+		lowest := -1
+		for f, c := range round.Counts {
+			if c != fewest {
+				continue
+			}
+			switch {
+			case lowest < 0 || scores[f] < lowest:
+				out, lowest = []int{f}, scores[f]
+			case scores[f] == lowest:
+				out = append(out, f)
+			}
+		}
+		if len(out) == left {
+			result.Rounds = append(result.Rounds, round)
+			result.Tied = out
+			return result
+		}
+		round.Eliminated = out
+		result.Rounds = append(result.Rounds, round)
+		for _, f := range out {
+			standing[f] = false
+		}
+	}
+}
+
+// Standings orders films by their votes in the last round of r, then by
+// when they were knocked out, for showing the result as a ranking.
+func (r Result) Standings() []int {
+	films := 0
+	if len(r.Rounds) > 0 {
+		films = len(r.Rounds[0].Counts)
+	}
+	outRound := make([]int, films)
+	for i := range outRound {
+		outRound[i] = len(r.Rounds)
+	}
+	for i, round := range r.Rounds {
+		for _, f := range round.Eliminated {
+			outRound[f] = i
+		}
+	}
+	order := make([]int, films)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if outRound[a] != outRound[b] {
+			return outRound[a] > outRound[b]
+		}
+		return r.Rounds[min(outRound[a], len(r.Rounds)-1)].Counts[a] > r.Rounds[min(outRound[b], len(r.Rounds)-1)].Counts[b]
+	})
+	return order
+}
+
+// Summary describes the result in plain text, one line per round
+// followed by the winner.
+func (p *Poll) Summary() string {
+	r := p.Result()
+	if len(r.Rounds) == 0 {
+		return "No ballots were cast."
+	}
+
+	var b strings.Builder
+	for i, round := range r.Rounds {
+		var counts []string
+		for f, c := range round.Counts {
+			if c >= 0 {
+				counts = append(counts, fmt.Sprintf("%s %d", p.Films[f].Title, c))
+			}
+		}
+		fmt.Fprintf(&b, "Round %d: %s", i+1, strings.Join(counts, ", "))
+		if len(round.Eliminated) > 0 {
+			var out []string
+			for _, f := range round.Eliminated {
+				out = append(out, p.Films[f].Title)
+			}
+			fmt.Fprintf(&b, "; %s out", strings.Join(out, " and "))
+		}
+		b.WriteString("\n")
+	}
+
+	switch {
+	case r.Winner >= 0:
+		fmt.Fprintf(&b, "Winner: %s", p.Films[r.Winner])
+	case len(r.Tied) > 0:
+		var tied []string
+		for _, f := range r.Tied {
+			tied = append(tied, p.Films[f].String())
+		}
+		fmt.Fprintf(&b, "Tie between %s", strings.Join(tied, " and "))
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package poll
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	const (
+		a = iota
+		b
+		c
+		d
+	)
+	tests := []struct {
+		name       string
+		films      int
+		ballots    [][]int
+		winner     int
+		tied       []int
+		eliminated [][]int
+	}{
+		{
+			name:    "no ballots",
+			films:   3,
+			winner:  -1,
+			ballots: nil,
+		},
+		{
+			name:       "first round majority",
+			films:      3,
+			ballots:    [][]int{{a}, {a, b}, {c}},
+			winner:     a,
+			eliminated: [][]int{nil},
+		},
+		{
+			// C and D tie for fewest; D is ranked lower overall, so it
+			// goes first. Then all three tie and C has the lowest score.
+			name:       "tie for last broken by ranking",
+			films:      4,
+			ballots:    [][]int{{a}, {a}, {b}, {b}, {c, b}, {d, c}},
+			winner:     b,
+			eliminated: [][]int{{d}, {c}, nil},
+		},
+		{
+			name:       "level films go out together",
+			films:      4,
+			ballots:    [][]int{{a}, {a}, {b}, {c}, {d}},
+			winner:     a,
+			eliminated: [][]int{{b, c, d}, nil},
+		},
+		{
+			name:       "tie for the win",
+			films:      2,
+			ballots:    [][]int{{a}, {b}},
+			winner:     -1,
+			tied:       []int{a, b},
+			eliminated: [][]int{nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ballots []Ballot
+			for _, r := range tt.ballots {
+				ballots = append(ballots, Ballot{Ranking: r})
+			}
+			r := count(tt.films, ballots)
+			if r.Winner != tt.winner {
+				t.Errorf("winner = %d, want %d", r.Winner, tt.winner)
+			}
+			if !reflect.DeepEqual(r.Tied, tt.tied) {
+				t.Errorf("tied = %v, want %v", r.Tied, tt.tied)
+			}
+			var eliminated [][]int
+			for _, round := range r.Rounds {
+				eliminated = append(eliminated, round.Eliminated)
+			}
+			if !reflect.DeepEqual(eliminated, tt.eliminated) {
+				t.Errorf("eliminated = %v, want %v", eliminated, tt.eliminated)
+			}
+		})
+	}
+}
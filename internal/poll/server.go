@@ -0,0 +1,207 @@
+package poll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var page = template.Must(template.New("poll").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 36em; padding: 0 1em; color: #222; }
+h1 { color: #00A86B; }
+li { margin: 0.4em 0; }
+select, input, button { font-size: 1em; padding: 0.2em 0.4em; }
+button { background: #00A86B; color: #fff; border: 0; padding: 0.5em 1.2em; }
+.note { color: #555; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Voted}}<p class="note">Thanks, {{.Voted}}. Your ballot is in; vote again under the same name to change it.</p>{{end}}
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+{{if .Closed}}
+<p>Voting has closed.{{if .Winner}} The winner is <strong>{{.Winner}}</strong>.{{end}}</p>
+{{else}}
+<p class="note">Number the films in the order you would like to watch them, 1 for your favourite. Leave out any you would rather skip.</p>
+<form method="post" action="/vote">
+<p><label>Your name <input name="voter" value="{{.Voter}}" required maxlength="40"></label></p>
+<ol>
+{{range $i, $f := .Films}}<li><select name="rank-{{$i}}"><option value="">–</option>{{range $.Ranks}}<option>{{.}}</option>{{end}}</select> {{$f}}</li>
+{{end}}</ol>
+<p><button type="submit">Vote</button></p>
+</form>
+{{end}}
+<p class="note">{{.Ballots}} ballot(s) so far.</p>
+</body>
+</html>
+`))
+
+type pageData struct {
+	Title   string
+	Films   []Film
+	Ranks   []int
+	Voter   string
+	Voted   string
+	Error   string
+	Closed  bool
+	Winner  string
+	Ballots int
+}
+
+// Handler serves the voting page at /, takes ballots posted to /vote and
+// reports the ballots and current result as JSON at /results.
+func (p *Poll) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", p.servePage)
+	mux.HandleFunc("POST /vote", p.serveVote)
+	mux.HandleFunc("GET /results", p.serveResults)
+	return mux
+}
+
+func (p *Poll) render(w http.ResponseWriter, status int, data pageData) {
+	data.Title = p.Title
+	data.Films = p.Films
+	data.Closed = p.Closed()
+	data.Ballots = len(p.Ballots())
+	for i := range p.Films {
+		data.Ranks = append(data.Ranks, i+1)
+	}
+	if data.Closed {
+		if r := p.Result(); r.Winner >= 0 {
+			data.Winner = p.Films[r.Winner].String()
+		}
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	page.Execute(w, data)
+}
+
+func (p *Poll) servePage(w http.ResponseWriter, r *http.Request) {
+	voted := r.URL.Query().Get("voted")
+	p.render(w, http.StatusOK, pageData{Voter: voted, Voted: voted})
+}
+
+func (p *Poll) serveVote(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		p.render(w, http.StatusBadRequest, pageData{Error: "Could not read the ballot."})
+		return
+	}
+	voter := r.PostForm.Get("voter")
+
+	// Each film's rank is its own field; order the films by them.
+	byRank := map[int]int{}
+	rankOf := map[int]int{}
+	var ranking []int
+	for i := range p.Films {
+		value := r.PostForm.Get("rank-" + strconv.Itoa(i))
+		if value == "" {
+			continue
+		}
+		rank, err := strconv.Atoi(value)
+		if err != nil || rank < 1 || rank > len(p.Films) {
+			p.render(w, http.StatusBadRequest, pageData{Voter: voter, Error: fmt.Sprintf("%q is not a rank.", value)})
+			return
+		}
+		if other, ok := byRank[rank]; ok {
+			p.render(w, http.StatusBadRequest, pageData{Voter: voter,
+				Error: fmt.Sprintf("%s and %s are both ranked %d; give each film its own number.", p.Films[other].Title, p.Films[i].Title, rank)})
+			return
+		}
+		byRank[rank] = i
+		rankOf[i] = rank
+		ranking = append(ranking, i)
+	}
+	sort.Slice(ranking, func(a, b int) bool {
+		return rankOf[ranking[a]] < rankOf[ranking[b]]
+	})
+
+	if err := p.Vote(voter, ranking); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrClosed) {
+			status = http.StatusConflict
+		}
+		p.render(w, status, pageData{Voter: voter, Error: capitalize(err.Error()) + "."})
+		return
+	}
+	http.Redirect(w, r, "/?voted="+url.QueryEscape(voter), http.StatusSeeOther)
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func (p *Poll) serveResults(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Title   string   `json:"title"`
+		Films   []Film   `json:"films"`
+		Closed  bool     `json:"closed"`
+		Ballots []Ballot `json:"ballots"`
+		Tally   []int    `json:"first_choices"`
+		Result  Result   `json:"result"`
+	}{p.Title, p.Films, p.Closed(), p.Ballots(), p.Tally(), p.Result()})
+}
+
+// Server serves a poll over HTTP.
+type Server struct {
+	http     *http.Server
+	listener net.Listener
+}
+
+// Serve starts serving p on addr, e.g. ":8080", in the background.
+func Serve(p *Poll, addr string) (*Server, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("could not listen on %s: %w", addr, err)
+	}
+	s := &Server{
+		http:     &http.Server{Handler: p.Handler(), ReadHeaderTimeout: 10 * time.Second},
+		listener: l,
+	}
+	go s.http.Serve(l)
+	return s, nil
+}
+
+// URLs lists the addresses other people on the network can open, with
+// the loopback address last.
+func (s *Server) URLs() []string {
+	port := s.listener.Addr().(*net.TCPAddr).Port
+	var urls []string
+	if host := s.listener.Addr().(*net.TCPAddr).IP; !host.IsUnspecified() {
+		return []string{fmt.Sprintf("http://%s/", net.JoinHostPort(host.String(), strconv.Itoa(port)))}
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, a := range addrs {
+			ip, ok := a.(*net.IPNet)
+			if ok && ip.IP.To4() != nil && !ip.IP.IsLoopback() && !ip.IP.IsLinkLocalUnicast() {
+				urls = append(urls, fmt.Sprintf("http://%s:%d/", ip.IP, port))
+			}
+		}
+	}
+	return append(urls, fmt.Sprintf("http://localhost:%d/", port))
+}
+
+// Close stops the server, letting requests in progress finish.
+func (s *Server) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return s.http.Shutdown(ctx)
+}
@@ -0,0 +1,161 @@
+package poll
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestPoll() *Poll {
+	return New("Movie night", []Film{
+		{Title: "Parasite", Year: 2019},
+		{Title: "Heat", Year: 1995},
+		{Title: "Alien", Year: 1979},
+	})
+}
+
+func get(t *testing.T, h http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func vote(t *testing.T, h http.Handler, form url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/vote", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestServePage(t *testing.T) {
+	h := newTestPoll().Handler()
+
+	w := get(t, h, "/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET / = %d, want %d", w.Code, http.StatusOK)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"<title>Movie night</title>", "Parasite (2019)", `name="rank-2"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page does not contain %q", want)
+		}
+	}
+
+	if w := get(t, h, "/elsewhere"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /elsewhere = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestServeVote(t *testing.T) {
+	p := newTestPoll()
+	h := p.Handler()
+
+	w := vote(t, h, url.Values{"voter": {"Alice"}, "rank-0": {"2"}, "rank-2": {"1"}})
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("POST /vote = %d, want %d", w.Code, http.StatusSeeOther)
+	}
+	if got := w.Header().Get("Location"); got != "/?voted=Alice" {
+		t.Errorf("redirect to %q, want %q", got, "/?voted=Alice")
+	}
+
+	// Voting again under the same name replaces the ballot.
+	vote(t, h, url.Values{"voter": {"alice "}, "rank-1": {"1"}})
+	ballots := p.Ballots()
+	if len(ballots) != 1 {
+		t.Fatalf("%d ballots after a repeat vote, want 1", len(ballots))
+	}
+	if got := ballots[0].Ranking; len(got) != 1 || got[0] != 1 {
+		t.Errorf("ranking = %v, want [1]", got)
+	}
+}
+
+func TestServeVoteRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		form url.Values
+		want string
+	}{
+		{"no name", url.Values{"rank-0": {"1"}}, "A name is needed"},
+		{"nothing ranked", url.Values{"voter": {"Bob"}}, "Rank at least one film"},
+		{"not a number", url.Values{"voter": {"Bob"}, "rank-0": {"first"}}, "is not a rank"},
+		{"out of range", url.Values{"voter": {"Bob"}, "rank-0": {"4"}}, "is not a rank"},
+		{"same rank twice", url.Values{"voter": {"Bob"}, "rank-0": {"1"}, "rank-1": {"1"}}, "are both ranked 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPoll()
+			w := vote(t, p.Handler(), tt.form)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("POST /vote = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("page does not explain %q", tt.want)
+			}
+			if n := len(p.Ballots()); n != 0 {
+				t.Errorf("%d ballots recorded, want 0", n)
+			}
+		})
+	}
+}
+
+func TestServeVoteClosed(t *testing.T) {
+	p := newTestPoll()
+	p.Close()
+	w := vote(t, p.Handler(), url.Values{"voter": {"Bob"}, "rank-0": {"1"}})
+	if w.Code != http.StatusConflict {
+		t.Errorf("POST /vote = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestServeResults(t *testing.T) {
+	p := newTestPoll()
+	h := p.Handler()
+	// Alien has the fewest first choices and goes out, passing Erin's
+	// vote on to Parasite.
+	for _, form := range []url.Values{
+		{"voter": {"Alice"}, "rank-0": {"1"}},
+		{"voter": {"Bob"}, "rank-0": {"1"}},
+		{"voter": {"Carol"}, "rank-1": {"1"}, "rank-0": {"2"}},
+		{"voter": {"Dan"}, "rank-1": {"1"}},
+		{"voter": {"Erin"}, "rank-2": {"1"}, "rank-0": {"2"}},
+	} {
+		if w := vote(t, h, form); w.Code != http.StatusSeeOther {
+			t.Fatalf("POST /vote = %d, want %d", w.Code, http.StatusSeeOther)
+		}
+	}
+	p.Close()
+
+	w := get(t, h, "/results")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /results = %d, want %d", w.Code, http.StatusOK)
+	}
+	var got struct {
+		Title   string   `json:"title"`
+		Closed  bool     `json:"closed"`
+		Ballots []Ballot `json:"ballots"`
+		Tally   []int    `json:"first_choices"`
+		Result  Result   `json:"result"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Movie night" || !got.Closed || len(got.Ballots) != 5 {
+		t.Errorf("got title %q, closed %v, %d ballots", got.Title, got.Closed, len(got.Ballots))
+	}
+	if want := []int{2, 2, 1}; !slices.Equal(got.Tally, want) {
+		t.Errorf("first choices = %v, want %v", got.Tally, want)
+	}
+	if len(got.Result.Rounds) != 2 || !slices.Equal(got.Result.Rounds[0].Eliminated, []int{2}) {
+		t.Errorf("rounds = %+v, want Alien out after the first", got.Result.Rounds)
+	}
+	if got.Result.Winner != 0 {
+		t.Errorf("winner = %d, want 0", got.Result.Winner)
+	}
+}
@@ -0,0 +1,106 @@
+// Package store keeps data the user builds up over time, such as followed
+// lists, as JSON files under the user's config directory. Unlike the cache
+// these entries never expire.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/atomicfile"
+)
+
+// Store is one named directory of JSON entries.
+type Store struct {
+	dir string
+}
+
+// Open returns the store called name, creating its directory if needed.
+func Open(name string) (*Store, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("could not find config directory: %w", err)
+	}
+	return OpenDir(filepath.Join(base, "lettercli", name))
+}
+
+// OpenDir returns a store kept in dir, creating it if needed.
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Keys are escaped so that ones like "owner/slug" make a single readable
+// file name.
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
+
+// Load decodes the entry stored under key into v. It reports false, with
+// no error, if there is no such entry.
+func (s *Store) Load(key string, v any) (bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("invalid entry %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Save stores v under key, replacing any existing entry. The entry is
+// written to a temporary file and renamed into place so an interrupted
+// write never leaves a truncated entry behind.
+func (s *Store) Save(key string, v any) error {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	if err := atomicfile.WriteFile(s.path(key), data, 0o644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the entry stored under key. Deleting a missing entry is
+// not an error.
+func (s *Store) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+// Keys returns the keys of every entry, sorted.
+func (s *Store) Keys() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.dir, err)
+	}
+	var keys []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		if key, err := url.PathUnescape(name); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,190 @@
+package termimg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resize scales src to w by h pixels, averaging the source pixels that
+// fall into each destination pixel.
+func resize(src image.Image, w, h int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	b := src.Bounds()
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := max(b.Min.Y+(y+1)*b.Dy()/h, y0+1)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := max(b.Min.X+(x+1)*b.Dx()/w, x0+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// kittyImageID identifies the picture to the terminal so it can be
+// deleted again.
+const kittyImageID = 4242
+
+var kittyDelete = fmt.Sprintf("\x1b_Ga=d,d=I,i=%d,q=2\x1b\\", kittyImageID)
+
+// encodeKitty transmits img as PNG, split into chunks of at most 4096
+// bytes as the protocol requires, and places it over cols by rows cells.
+// q=2 stops the terminal answering, which would otherwise arrive as key
+// presses.
+func encodeKitty(img image.Image, cols, rows int) (string, error) {
+	data, err := encodePNG(img)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.StdEncoding.EncodeToString(data)
+
+	const chunkSize = 4096
+	var sb strings.Builder
+	for i := 0; i < len(payload); i += chunkSize {
+		end := min(i+chunkSize, len(payload))
+		more := 0
+		if end < len(payload) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&sb, "\x1b_Ga=T,f=100,i=%d,c=%d,r=%d,C=1,q=2,m=%d;", kittyImageID, cols, rows, more)
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;", more)
+		}
+		sb.WriteString(payload[i:end])
+		sb.WriteString("\x1b\\")
+	}
+	return sb.String(), nil
+}
+
+// encodeITerm2 writes img as an iTerm2 inline file sized in cells.
+func encodeITerm2(img image.Image, cols, rows int) (string, error) {
+	data, err := encodePNG(img)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0:%s\a",
+		len(data), cols, rows, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// encodeSixel dithers img to the web-safe palette and writes it as
+// Sixel bands of six pixel rows, run-length encoding each colour.
+func encodeSixel(img *image.RGBA) string {
+	b := img.Bounds()
+	pal := image.NewPaletted(b, palette.WebSafe)
+	draw.FloydSteinberg.Draw(pal, b, img, b.Min)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\x1bPq\"1;1;%d;%d", b.Dx(), b.Dy())
+	for i, c := range pal.Palette {
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, bl*100/0xffff)
+	}
+
+	w, h := b.Dx(), b.Dy()
+	for y0 := 0; y0 < h; y0 += 6 {
+		used := make([]bool, len(pal.Palette))
+		for y := y0; y < min(y0+6, h); y++ {
+			for x := 0; x < w; x++ {
+				used[pal.ColorIndexAt(x, y)] = true
+			}
+		}
+
+		first := true
+		for ci, inBand := range used {
+			if !inBand {
+				continue
+			}
+			if !first {
+				sb.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(&sb, "#%d", ci)
+
+			var prev byte
+			run := 0
+			for x := 0; x < w; x++ {
+				var bits byte
+				for dy := 0; dy < 6 && y0+dy < h; dy++ {
+					if int(pal.ColorIndexAt(x, y0+dy)) == ci {
+						bits |= 1 << dy
+					}
+				}
+				ch := 63 + bits
+				if ch == prev {
+					run++
+					continue
+				}
+				writeSixelRun(&sb, prev, run)
+				prev, run = ch, 1
+			}
+			writeSixelRun(&sb, prev, run)
+		}
+		sb.WriteByte('-')
+	}
+	sb.WriteString("\x1b\\")
+	return sb.String()
+}
+
+func writeSixelRun(sb *strings.Builder, ch byte, run int) {
+	switch {
+	case run == 0:
+	case run > 3:
+		fmt.Fprintf(sb, "!%d%c", run, ch)
+	default:
+		sb.WriteString(strings.Repeat(string(ch), run))
+	}
+}
+
+// halfBlocks draws two pixel rows per line with "▀", the upper pixel as
+// the foreground and the lower one as the background. lipgloss reduces
+// the colours to whatever the terminal supports.
+func halfBlocks(img *image.RGBA) []string {
+	b := img.Bounds()
+	var lines []string
+	for y := 0; y < b.Dy(); y += 2 {
+		var sb strings.Builder
+		for x := 0; x < b.Dx(); x++ {
+			style := lipgloss.NewStyle().Foreground(hexColor(img.RGBAAt(x, y)))
+			if y+1 < b.Dy() {
+				style = style.Background(hexColor(img.RGBAAt(x, y+1)))
+			}
+			sb.WriteString(style.Render("▀"))
+		}
+		lines = append(lines, sb.String())
+	}
+	return lines
+}
+
+func hexColor(c color.RGBA) lipgloss.Color {
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+}
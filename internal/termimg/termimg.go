@@ -0,0 +1,177 @@
+// Package termimg draws images in the terminal. It speaks the Kitty
+// graphics protocol, Sixel and iTerm2 inline images, and falls back to
+// Unicode half blocks on terminals that support none of them.
+//
+// Bubble Tea lays out views as text, so a Picture is used in two steps:
+// Placeholder returns a block of the right size to place in the layout,
+// and Composite turns the finished view into what is written to the
+// terminal.
+package termimg
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"strings"
+)
+
+// Protocol is a way of getting pixels onto the screen.
+type Protocol int
+
+const (
+	HalfBlocks Protocol = iota
+	Kitty
+	Sixel
+	ITerm2
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case Kitty:
+		return "kitty"
+	case Sixel:
+		return "sixel"
+	case ITerm2:
+		return "iterm2"
+	default:
+		return "halfblocks"
+	}
+}
+
+// ParseProtocol reads a protocol name as returned by Protocol.String.
+func ParseProtocol(name string) (Protocol, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "kitty":
+		return Kitty, true
+	case "sixel":
+		return Sixel, true
+	case "iterm2", "iterm":
+		return ITerm2, true
+	case "halfblocks", "blocks", "unicode":
+		return HalfBlocks, true
+	}
+	return HalfBlocks, false
+}
+
+// Detect guesses the best protocol from the environment. Setting
+// LETTERCLI_IMAGE_PROTOCOL to a protocol name overrides the guess.
+func Detect() Protocol {
+	if p, ok := ParseProtocol(os.Getenv("LETTERCLI_IMAGE_PROTOCOL")); ok {
+		return p
+	}
+	// Multiplexers do not pass graphics through reliably.
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return HalfBlocks
+	}
+
+	term := os.Getenv("TERM")
+	program := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || program == "ghostty":
+		return Kitty
+	case program == "iTerm.app" || program == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return ITerm2
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "foot") ||
+		term == "mlterm" || term == "contour":
+		return Sixel
+	}
+	return HalfBlocks
+}
+
+// Cell dimensions assumed when an image has to be sized in pixels.
+const (
+	cellWidth  = 10
+	cellHeight = 20
+)
+
+// marker fills the cells a graphics image will cover until Composite
+// replaces it. U+2800 is blank and one cell wide.
+const marker = "⠀"
+
+// Picture is an image prepared for one protocol at a fixed size in cells.
+type Picture struct {
+	Protocol Protocol
+	Cols     int
+	Rows     int
+
+	// blocks holds the rendered rows for HalfBlocks, escape holds the
+	// graphics sequence for every other protocol.
+	blocks []string
+	escape string
+}
+
+// Render scales img to fit within cols by rows cells, keeping its aspect
+// ratio, and encodes it for p.
+func Render(img image.Image, cols, rows int, p Protocol) (Picture, error) {
+	b := img.Bounds()
+	if b.Empty() || cols <= 0 || rows <= 0 {
+		return Picture{}, fmt.Errorf("nothing to draw")
+	}
+
+	// A cell is about twice as tall as it is wide.
+	if fit := rows * 2 * b.Dx() / b.Dy(); fit < cols {
+		cols = max(fit, 1)
+	}
+	if fit := cols * b.Dy() / (2 * b.Dx()); fit < rows {
+		rows = max(fit, 1)
+	}
+
+	pic := Picture{Protocol: p, Cols: cols, Rows: rows}
+	var err error
+	switch p {
+	case Kitty:
+		pic.escape, err = encodeKitty(resize(img, cols*cellWidth, rows*cellHeight), cols, rows)
+	case Sixel:
+		pic.escape = encodeSixel(resize(img, cols*cellWidth, rows*cellHeight))
+	case ITerm2:
+		pic.escape, err = encodeITerm2(resize(img, cols*cellWidth, rows*cellHeight), cols, rows)
+	default:
+		pic.blocks = halfBlocks(resize(img, cols, rows*2))
+	}
+	if err != nil {
+		return Picture{}, err
+	}
+	return pic, nil
+}
+
+// Placeholder returns a Cols by Rows block to put in the layout where the
+// picture should appear.
+func (p Picture) Placeholder() string {
+	if p.Protocol == HalfBlocks {
+		return strings.Join(p.blocks, "\n")
+	}
+	line := strings.Repeat(marker, p.Cols)
+	lines := make([]string, p.Rows)
+	for i := range lines {
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Composite prepares a view containing the placeholder for output. The
+// graphics sequence is written where the block starts and the cursor is
+// moved over the covered cells, so redrawing the text around the picture
+// never paints over it.
+func (p Picture) Composite(view string) string {
+	if p.Protocol == HalfBlocks || p.Cols == 0 {
+		return view
+	}
+	line := strings.Repeat(marker, p.Cols)
+	skip := fmt.Sprintf("\x1b[%dC", p.Cols)
+	first := strings.Index(view, line)
+	if first < 0 {
+		return view
+	}
+	rest := strings.ReplaceAll(view[first+len(line):], line, skip)
+	return view[:first] + "\x1b7" + p.escape + "\x1b8" + skip + rest
+}
+
+// Clear returns the sequence that removes the picture from the screen
+// once it is no longer part of the view. Only Kitty keeps images on
+// screen after the text over them has been redrawn.
+func (p Picture) Clear() string {
+	if p.Protocol == Kitty {
+		return kittyDelete
+	}
+	return ""
+}
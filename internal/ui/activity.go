@@ -0,0 +1,544 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/links"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// activityRefresh is how often the feed fetches everyone's activity
+	// again while it is open.
+	activityRefresh = 5 * time.Minute
+
+	// maxActivityFriends bounds how many of the people a user follows
+	// the feed is collected from, in the order Letterboxd lists them.
+	maxActivityFriends = 100
+)
+
+// activityRatings are the minimum ratings 'r' cycles through; 0 shows
+// unrated activity too.
+var activityRatings = []float64{0, 3, 3.5, 4, 4.5, 5}
+
+// Friend is someone on a user's following or followers list.
+type Friend struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// activityItem is one diary entry, rating or review by someone a user
+// follows.
+type activityItem struct {
+	Friend  string  `json:"-"`
+	Kind    string  `json:"kind"` // "diary", "rating" or "review"
+	Title   string  `json:"title"`
+	Year    int     `json:"year"`
+	Slug    string  `json:"slug"`
+	Rating  float64 `json:"rating"`
+	Date    string  `json:"date"` // 2006-01-02, or empty when unknown
+	Rewatch bool    `json:"rewatch"`
+	Review  string  `json:"review"`
+}
+
+func (a activityItem) movie() Movie {
+	return Movie{Title: a.Title, Year: a.Year, Slug: a.Slug}
+}
+
+func (a activityItem) key() string {
+	return a.Friend + "/" + a.Slug + "/" + a.Date + "/" + a.Kind
+}
+
+// label says what the friend did, as shown in the Activity column.
+func (a activityItem) label() string {
+	var label string
+	switch a.Kind {
+	case "review":
+		label = "reviewed"
+	case "rating":
+		label = "rated"
+	default:
+		label = "watched"
+	}
+	if a.Rewatch {
+		label += " (rewatch)"
+	}
+	return label
+}
+
+// activityDate shows the day of an item, leaving out the year for this
+// year's activity.
+func activityDate(date string, now time.Time) string {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	if day.Year() == now.Year() {
+		return day.Format("2 Jan")
+	}
+	return day.Format("2 Jan 2006")
+}
+
+// loadFollows fetches everyone on a user's "following" or "followers"
+// list.
+func loadFollows(username, kind string) ([]Friend, error) {
+	out, err := runPyExec("get_follows", username, kind)
+	if err != nil {
+		return nil, err
+	}
+	var people []Friend
+	if err := json.Unmarshal(out, &people); err != nil {
+		return nil, fmt.Errorf("failed to parse %s JSON: %w", kind, err)
+	}
+	return people, nil
+}
+
+// loadActivity fetches a user's recent diary entries and reviews.
+func loadActivity(username string) ([]activityItem, error) {
+	out, err := runPyExec("get_recent_activity", username)
+	if err != nil {
+		return nil, err
+	}
+	var items []activityItem
+	if err := json.Unmarshal(out, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse activity JSON: %w", err)
+	}
+	for i := range items {
+		items[i].Friend = username
+	}
+	return items, nil
+}
+
+type activityMsg struct {
+	run      int
+	username string
+	friends  []Friend
+	items    []activityItem
+	failed   int
+	err      error
+	at       time.Time
+}
+
+type activityTickMsg struct{ run int }
+
+// fetchActivity collects the recent activity of everyone username
+// follows, a few people at a time, newest first. The following list is
+// fetched first unless it is already known.
+func fetchActivity(run int, username string, friends []Friend) tea.Cmd {
+	return func() tea.Msg {
+		msg := activityMsg{run: run, username: username, friends: friends}
+		if msg.friends == nil {
+			var err error
+			if msg.friends, err = loadFollows(username, "following"); err != nil {
+				msg.err = err
+				return msg
+			}
+		}
+
+		polled := msg.friends[:min(len(msg.friends), maxActivityFriends)]
+		byFriend := make([][]activityItem, len(polled))
+		msg.failed = forEachBounded(len(polled), func(i int) error {
+			items, err := loadActivity(polled[i].Username)
+			byFriend[i] = items
+			return err
+		})
+
+		if len(polled) > 0 && msg.failed == len(polled) {
+			msg.err = fmt.Errorf("could not fetch activity for any of the %d people @%s follows", len(polled), username)
+			return msg
+		}
+		for _, items := range byFriend {
+			msg.items = append(msg.items, items...)
+		}
+		// Dates are days, so entries from the same day keep the order
+		// each diary lists them in.
+		sort.SliceStable(msg.items, func(i, j int) bool { return msg.items[i].Date > msg.items[j].Date })
+		msg.at = time.Now()
+		return msg
+	}
+}
+
+func scheduleActivityRefresh(run int) tea.Cmd {
+	return tea.Tick(activityRefresh, func(time.Time) tea.Msg { return activityTickMsg{run} })
+}
+
+// ActivityModel is a feed of the recent diary entries, ratings and
+// reviews of everyone a user follows. It refreshes itself while open.
+type ActivityModel struct {
+	input      textinput.Model
+	spinner    spinner.Model
+	table      table.Model
+	baseStyle  lipgloss.Style
+	loading    bool
+	refreshing bool
+	showTable  bool
+	quitting   bool
+	err        error
+	refreshErr error
+	username   string
+	friends    []Friend
+	items      []activityItem
+	shown      []activityItem
+	friend     string
+	minRating  float64
+	failed     int
+	run        int
+	updated    time.Time
+	back       tea.Model
+	width      int
+}
+
+func NewActivityModel() ActivityModel {
+	ti := textinput.New()
+	ti.Placeholder = "Enter Letterboxd username..."
+	ti.Focus()
+	ti.CharLimit = 128
+	ti.Width = 40
+	ti.Prompt = "Username: "
+	ti.PromptStyle = watchInputPromptStyle
+	ti.Cursor.Style = watchInputCursorStyle
+	ti.TextStyle = watchInputTextStyle
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Date", Width: 12},
+			{Title: "Friend", Width: 18},
+			{Title: "Film", Width: 36},
+			{Title: "Year", Width: 6},
+			{Title: "Rating", Width: 7},
+			{Title: "Activity", Width: 20},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	return ActivityModel{
+		input:     ti,
+		spinner:   sp,
+		table:     t,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+		minRating: activityRatings[0],
+	}
+}
+
+// NewActivityModelFor opens the feed for username straight away; Esc
+// returns to back.
+func NewActivityModelFor(username string, back tea.Model) ActivityModel {
+	m := NewActivityModel()
+	m.input.SetValue(username)
+	m.input.Blur()
+	m.username = username
+	m.loading = true
+	m.back = back
+	return m
+}
+
+func (m ActivityModel) Init() tea.Cmd {
+	if m.loading {
+		return tea.Batch(m.spinner.Tick, fetchActivity(m.run, m.username, nil))
+	}
+	return textinput.Blink
+}
+
+func (m ActivityModel) goBack() (tea.Model, tea.Cmd) {
+	if m.back != nil {
+		return m.back, nil
+	}
+	return NewMenuModel(), nil
+}
+
+// refresh fetches everyone's activity again, keeping the feed on screen
+// until it arrives.
+func (m *ActivityModel) refresh() tea.Cmd {
+	m.run++
+	m.refreshing = true
+	return tea.Batch(m.spinner.Tick, fetchActivity(m.run, m.username, m.friends))
+}
+
+// friendOptions are the usernames 'f' cycles through: everyone, then
+// each friend with any activity.
+func (m ActivityModel) friendOptions() []string {
+	seen := map[string]bool{}
+	options := []string{""}
+	for _, a := range m.items {
+		if !seen[a.Friend] {
+			seen[a.Friend] = true
+			options = append(options, a.Friend)
+		}
+	}
+	sort.Strings(options[1:])
+	return options
+}
+
+// updateRows applies the filters, keeping the selected item selected if
+// it is still shown.
+func (m *ActivityModel) updateRows() {
+	var selected string
+	if i := m.table.Cursor(); i >= 0 && i < len(m.shown) {
+		selected = m.shown[i].key()
+	}
+
+	m.shown = nil
+	for _, a := range m.items {
+		if m.friend != "" && a.Friend != m.friend {
+			continue
+		}
+		if a.Rating < m.minRating {
+			continue
+		}
+		m.shown = append(m.shown, a)
+	}
+
+	now := time.Now()
+	cursor := 0
+	rows := make([]table.Row, len(m.shown))
+	for i, a := range m.shown {
+		rows[i] = table.Row{activityDate(a.Date, now), a.Friend, a.Title, fmt.Sprintf("%d", a.Year), starLabel(a.Rating), a.label()}
+		if a.key() == selected {
+			cursor = i
+		}
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(cursor)
+}
+
+func (m ActivityModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			if m.back == nil && (m.err != nil || m.showTable) {
+				m.run++
+				m.err = nil
+				m.showTable = false
+				m.loading = false
+				m.refreshing = false
+				m.friends = nil
+				m.items = nil
+				m.friend = ""
+				m.input.Focus()
+				return m, textinput.Blink
+			}
+			return m.goBack()
+
+		case "enter":
+			if m.showTable {
+				if i := m.table.Cursor(); i >= 0 && i < len(m.shown) {
+					return newFilmDetailsModel(m.shown[i].movie(), m)
+				}
+				return m, nil
+			}
+			if m.loading {
+				return m, nil
+			}
+			value := strings.TrimSpace(m.input.Value())
+			if value == "" {
+				return m, nil
+			}
+			if links.IsLink(value) {
+				return openLink(value, m)
+			}
+			m.username = value
+			m.loading = true
+			m.run++
+			m.input.Blur()
+			return m, tea.Batch(m.spinner.Tick, fetchActivity(m.run, m.username, nil))
+		}
+
+		if m.showTable {
+			// Refresh ticks that arrived while a film was open went to
+			// the film's screen, so catch up on the first key press back.
+			if !m.refreshing && time.Since(m.updated) >= activityRefresh {
+				refreshCmd := m.refresh()
+				next, cmd := m.Update(msg)
+				return next, tea.Batch(refreshCmd, cmd)
+			}
+			switch msg.String() {
+			case "q":
+				m.quitting = true
+				return m, tea.Quit
+			case "f":
+				m.friend = nextOption(m.friendOptions(), m.friend)
+				m.updateRows()
+				return m, nil
+			case "r":
+				m.minRating = activityRatings[(indexOfActivityRating(m.minRating)+1)%len(activityRatings)]
+				m.updateRows()
+				return m, nil
+			case "u":
+				if !m.refreshing {
+					return m, m.refresh()
+				}
+				return m, nil
+			}
+		}
+
+	case activityMsg:
+		if msg.run != m.run || msg.username != m.username {
+			return m, nil
+		}
+		if m.showTable {
+			m.refreshing = false
+			m.refreshErr = msg.err
+			if msg.err != nil {
+				m.updated = time.Now()
+				return m, scheduleActivityRefresh(m.run)
+			}
+		} else {
+			m.loading = false
+			if msg.err != nil {
+				m.err = msg.err
+				return m, nil
+			}
+			m.showTable = true
+		}
+		m.friends = msg.friends
+		m.items = msg.items
+		m.failed = msg.failed
+		m.updated = msg.at
+		if m.friend != "" && !slices.Contains(m.friendOptions(), m.friend) {
+			m.friend = ""
+		}
+		m.updateRows()
+		return m, scheduleActivityRefresh(m.run)
+
+	case activityTickMsg:
+		if msg.run == m.run && m.showTable && !m.refreshing {
+			return m, m.refresh()
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.table.SetWidth(msg.Width - 4)
+	}
+
+	switch {
+	case m.loading || m.refreshing:
+		var spinCmd tea.Cmd
+		m.spinner, spinCmd = m.spinner.Update(msg)
+		if m.showTable {
+			m.table, cmd = m.table.Update(msg)
+			return m, tea.Batch(spinCmd, cmd)
+		}
+		return m, spinCmd
+	case m.showTable:
+		m.table, cmd = m.table.Update(msg)
+	default:
+		m.input, cmd = m.input.Update(msg)
+	}
+	return m, cmd
+}
+
+func indexOfActivityRating(r float64) int {
+	for i, v := range activityRatings {
+		if v == r {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m ActivityModel) filterLine() string {
+	parts := []string{"everyone"}
+	if m.friend != "" {
+		parts[0] = "@" + m.friend
+	}
+	if m.minRating > 0 {
+		parts = append(parts, "rated "+starLabel(m.minRating)+" or more")
+	}
+	polled := min(len(m.friends), maxActivityFriends)
+	if polled < len(m.friends) {
+		parts = append(parts, fmt.Sprintf("first %d of %d people followed", polled, len(m.friends)))
+	}
+	if m.refreshing {
+		parts = append(parts, m.spinner.View()+" refreshing")
+	} else {
+		parts = append(parts, "updated "+m.updated.Format("15:04"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (m ActivityModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+	if m.loading {
+		return fmt.Sprintf("\n\n   %s Collecting activity from everyone @%s follows...\n\n", m.spinner.View(), m.username)
+	}
+
+	if m.showTable {
+		header := lipgloss.JoinVertical(lipgloss.Left,
+			filmsTitleStyle.Render(fmt.Sprintf("Activity from people @%s follows", m.username)),
+			filmsFilterStyle.Render(m.filterLine()),
+		)
+		help := "(Enter to view film, 'f' friend, 'r' minimum rating, 'u' to refresh now, Esc to go back)"
+
+		var body string
+		switch {
+		case len(m.friends) == 0:
+			body = fmt.Sprintf("@%s does not follow anyone yet.", m.username)
+		case len(m.shown) == 0:
+			body = "No activity matches these filters."
+		default:
+			body = m.baseStyle.Render(m.table.View())
+		}
+		blocks := []string{header, body}
+		if i := m.table.Cursor(); i >= 0 && i < len(m.shown) && m.shown[i].Review != "" {
+			width := 80
+			if m.width > 8 {
+				width = m.width - 8
+			}
+			blocks = append(blocks, reviewPreview(m.shown[i].Review, width))
+		}
+		if m.failed > 0 {
+			blocks = append(blocks, fmt.Sprintf("(activity unavailable for %d people)", m.failed))
+		}
+		if m.refreshErr != nil {
+			blocks = append(blocks, fmt.Sprintf("(last refresh failed: %v)", m.refreshErr))
+		}
+		blocks = append(blocks, help)
+		return lipgloss.JoinVertical(lipgloss.Left, blocks...)
+	}
+
+	title := watchlistPageTitleStyle.Render("Friends' Activity")
+	inputBlock := lipgloss.JoinVertical(lipgloss.Left,
+		m.input.View(),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(strings.Repeat("─", m.input.Width+len(m.input.Prompt))),
+	)
+	help := watchHelpStyle.Render("type a username to see what the people they follow have been watching, and press enter")
+
+	final := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		inputBlock,
+		"\n\n\n",
+		help,
+	)
+	return lipgloss.NewStyle().Margin(1, 2).Render(final)
+}
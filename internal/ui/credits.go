@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	creditNameStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229")).
+			Width(30)
+
+	creditRoleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+)
+
+// creditLine is one line of the Cast & Crew tab. credit indexes
+// MovieDetails.Credits, or is -1 for headings, details and blank lines.
+type creditLine struct {
+	text   string
+	credit int
+}
+
+// creditDescription is the character played by a cast member or the job
+// of a crew member.
+func creditDescription(p Person) string {
+	if p.Department == "" {
+		if p.Character == "" {
+			return "Actor"
+		}
+		return p.Character
+	}
+	return roleLabel(p.Role)
+}
+
+// creditLines lays out the whole Cast & Crew tab; renderCredits shows the
+// part of it that fits on screen.
+func (m SearchModel) creditLines() []creditLine {
+	d := m.movieDetails
+	var lines []creditLine
+	add := func(text string) {
+		lines = append(lines, creditLine{text: text, credit: -1})
+	}
+
+	valueStyle := movieDetailValueStyle
+	if m.width > 0 {
+		valueStyle = valueStyle.Width(m.width - 24)
+	}
+	details := []struct {
+		key    string
+		values []string
+	}{
+		{"studios", d.Studios},
+		{"countries", d.Countries},
+		{"languages", d.Languages},
+		{"aka", d.AlternativeTitles},
+	}
+	for _, detail := range details {
+		if len(detail.values) == 0 {
+			continue
+		}
+		block := lipgloss.JoinHorizontal(lipgloss.Top,
+			movieDetailKeyStyle.Render(detail.key),
+			valueStyle.Render(strings.Join(detail.values, ", ")),
+		)
+		for _, l := range strings.Split(block, "\n") {
+			add(l)
+		}
+	}
+
+	heading := ""
+	for i, c := range d.Credits {
+		group := "cast"
+		if c.Department != "" {
+			group = strings.ToLower(c.Department)
+		}
+		if group != heading {
+			if len(lines) > 0 {
+				add("")
+			}
+			add(synopsisHeaderStyle.Render(group))
+			heading = group
+		}
+
+		prefix := "  "
+		if i == m.creditCursor {
+			prefix = navActiveStyle.Render("→ ")
+		}
+		text := prefix + creditNameStyle.Render(c.Name) + creditRoleStyle.Render(creditDescription(c))
+		lines = append(lines, creditLine{text: text, credit: i})
+	}
+	return lines
+}
+
+// creditsHeight is the number of lines of the Cast & Crew tab shown at once.
+func (m SearchModel) creditsHeight() int {
+	if m.height == 0 {
+		return 15
+	}
+	if h := m.height - 14; h > 5 {
+		return h
+	}
+	return 5
+}
+
+// moveCreditCursor handles a navigation key on the Cast & Crew tab and
+// scrolls so the selected credit stays visible along with its heading.
+func (m *SearchModel) moveCreditCursor(key string) {
+	n := len(m.movieDetails.Credits)
+	if n == 0 {
+		return
+	}
+	height := m.creditsHeight()
+
+	switch key {
+	case "up", "k":
+		m.creditCursor--
+	case "down", "j":
+		m.creditCursor++
+	case "pgup":
+		m.creditCursor -= height
+	case "pgdown":
+		m.creditCursor += height
+	case "home":
+		m.creditCursor = 0
+	case "end":
+		m.creditCursor = n - 1
+	}
+	if m.creditCursor < 0 {
+		m.creditCursor = 0
+	}
+	if m.creditCursor >= n {
+		m.creditCursor = n - 1
+	}
+
+	lines := m.creditLines()
+	selected := 0
+	for i, l := range lines {
+		if l.credit == m.creditCursor {
+			selected = i
+			break
+		}
+	}
+
+	top := selected
+	for top > 0 && lines[top-1].credit == -1 && selected-top+1 < height {
+		top--
+	}
+	if top < m.creditOffset {
+		m.creditOffset = top
+	}
+	if selected >= m.creditOffset+height {
+		m.creditOffset = selected - height + 1
+	}
+}
+
+func (m SearchModel) renderCredits() string {
+	lines := m.creditLines()
+	if len(m.movieDetails.Credits) == 0 {
+		if len(lines) == 0 {
+			return "No cast or crew listed."
+		}
+		lines = append(lines, creditLine{text: "\nNo cast or crew listed.", credit: -1})
+	}
+
+	height := m.creditsHeight()
+	start := m.creditOffset
+	if start > len(lines) {
+		start = len(lines)
+	}
+	end := start + height
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	visible := make([]string, 0, end-start+1)
+	for _, l := range lines[start:end] {
+		visible = append(visible, l.text)
+	}
+
+	if n := len(m.movieDetails.Credits); n > 0 {
+		position := fmt.Sprintf("%d/%d", m.creditCursor+1, n)
+		if start > 0 {
+			position = "↑ " + position
+		}
+		if end < len(lines) {
+			position += " ↓"
+		}
+		visible = append(visible, "", movieSubtitleStyle.Render(position))
+	}
+	return strings.Join(visible, "\n")
+}
@@ -1,16 +1,12 @@
 package ui
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"runtime"
 	"strings"
-	"time"
 
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
 	"github.com/charmbracelet/bubbles/paginator"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
@@ -51,37 +47,29 @@ type diaryResultMsg struct {
 	err     error
 }
 
-type exportDiaryResultMsg struct {
-	filePath string
-	err      error
-}
-
 type DiaryModel struct {
-	input               textinput.Model
-	spinner             spinner.Model
-	table               table.Model
-	paginator           paginator.Model
-	showSpinner         bool
-	showDiary           bool
-	submitted           bool
-	quitting            bool
-	err                 error
-	promptingExportPath bool
-	exportInput         textinput.Model
-	exportPath          string
-	exportErr           error
-	lastExportMsg       time.Time
-	diaryEntries        []DiaryEntry
-	targetUser          string
-	baseStyle           lipgloss.Style
-	width               int
+	input        textinput.Model
+	spinner      spinner.Model
+	table        table.Model
+	paginator    paginator.Model
+	showSpinner  bool
+	showDiary    bool
+	submitted    bool
+	quitting     bool
+	err          error
+	exporter     exportPrompt
+	exportStatus exportStatus
+	diaryEntries []DiaryEntry
+	targetUser   string
+	baseStyle    lipgloss.Style
+	width        int
 }
 
 func NewDiaryModel() DiaryModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter Letterboxd username..."
 	ti.Focus()
-	ti.CharLimit = 32
+	ti.CharLimit = 128
 	ti.Width = 40
 	ti.Prompt = "Username: "
 	ti.PromptStyle = diaryInputPromptStyle
@@ -97,14 +85,8 @@ func NewDiaryModel() DiaryModel {
 	p.ActiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Render("•")
 	p.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("•")
 
-	exportTi := textinput.New()
-	exportTi.Placeholder = "e.g., my_diary.csv or exports/diary.csv"
-	exportTi.CharLimit = 256
-	exportTi.Width = 60
-	exportTi.Prompt = "Export Path (relative): "
-	exportTi.PromptStyle = diaryInputPromptStyle.Copy()
-	exportTi.Cursor.Style = diaryInputCursorStyle.Copy()
-	exportTi.TextStyle = diaryInputTextStyle.Copy()
+	exporter := newExportPrompt("e.g., my_diary.csv or exports/diary.csv",
+		diaryInputPromptStyle, diaryInputCursorStyle, diaryInputTextStyle)
 
 	columns := []table.Column{
 		{Title: "Watched", Width: 10},
@@ -125,122 +107,72 @@ func NewDiaryModel() DiaryModel {
 	t.SetStyles(s)
 
 	return DiaryModel{
-		input:       ti,
-		spinner:     sp,
-		paginator:   p,
-		table:       t,
-		exportInput: exportTi,
-		baseStyle:   lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+		input:     ti,
+		spinner:   sp,
+		paginator: p,
+		table:     t,
+		exporter:  exporter,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
 	}
 }
 
-func callPythonGetDiary(username string) tea.Cmd {
-	return func() tea.Msg {
-		pyExecName := "get_diary"
-		if runtime.GOOS == "windows" {
-			pyExecName += ".exe"
-		}
-
-		baseDir := ""
-		snapDir := os.Getenv("SNAP")
-		if snapDir != "" {
-			baseDir = snapDir
-		} else {
-			goExecPath, err := os.Executable()
-			if err != nil {
-				return diaryResultMsg{err: fmt.Errorf("fatal: could not get executable path: %w", err)}
-			}
-			baseDir = filepath.Dir(goExecPath)
-		}
-
-		pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
-
-		if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
-			wd, _ := os.Getwd()
-			arch := runtime.GOARCH
-			osDir := runtime.GOOS + "_" + arch
-			altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
-
-			if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
-				pyExecPath = altPyExecPath
-			} else {
-				return diaryResultMsg{err: fmt.Errorf("python executable not found at %s or %s",
-					filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
-					filepath.Join("project_root", "dist_py", osDir, pyExecName))}
-			}
-		}
-
-		cmd := exec.Command(pyExecPath, username)
-		out, err := cmd.Output()
+// newUserDiaryModel loads username's diary straight away.
+func newUserDiaryModel(username string) (DiaryModel, tea.Cmd) {
+	m := NewDiaryModel()
+	m.input.SetValue(username)
+	m.input.Blur()
+	m.submitted = true
+	m.showSpinner = true
+	m.targetUser = username
+	return m, tea.Batch(m.spinner.Tick, callPythonGetDiary(username))
+}
 
-		if err != nil {
-			var errData map[string]string
-			if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
-				return diaryResultMsg{err: fmt.Errorf(errData["error"])}
-			}
-			return diaryResultMsg{err: fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))}
-		}
-		var maybeErr map[string]string
-		if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
-			return diaryResultMsg{err: fmt.Errorf(maybeErr["error"])}
-		}
-		var entries []DiaryEntry
-		if err := json.Unmarshal(out, &entries); err != nil {
-			return diaryResultMsg{err: fmt.Errorf("failed to parse diary JSON: %w", err)}
-		}
-		return diaryResultMsg{entries: entries}
+// loadDiary returns every diary entry of username, newest first.
+func loadDiary(username string) ([]DiaryEntry, error) {
+	out, err := runPyExec("get_diary", username)
+	if err != nil {
+		return nil, err
 	}
+	var entries []DiaryEntry
+	if err := json.Unmarshal(out, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse diary JSON: %w", err)
+	}
+	return entries, nil
 }
 
-func exportDiaryToCSV(entries []DiaryEntry, username, relativeFilePath string) tea.Cmd {
+func callPythonGetDiary(username string) tea.Cmd {
 	return func() tea.Msg {
-		filePath, err := filepath.Abs(relativeFilePath)
-		if err != nil {
-			return exportDiaryResultMsg{err: fmt.Errorf("invalid path format: %w", err)}
-		}
-		dir := filepath.Dir(filePath)
-		if dir != "." && dir != "/" {
-			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-				if os.IsPermission(err) {
-					return exportDiaryResultMsg{err: fmt.Errorf("permission denied creating directory %s", dir)}
-				}
-				return exportDiaryResultMsg{err: fmt.Errorf("failed to create directory %s: %w", dir, err)}
-			}
-		}
-		file, err := os.Create(filePath)
-		if err != nil {
-			if os.IsPermission(err) {
-				return exportDiaryResultMsg{err: fmt.Errorf("permission denied creating file %s", filePath)}
-			}
-			return exportDiaryResultMsg{err: fmt.Errorf("failed to create file %s: %w", filePath, err)}
-		}
-		defer file.Close()
-
-		writer := csv.NewWriter(file)
-		defer writer.Flush()
+		entries, err := loadDiary(username)
+		return diaryResultMsg{entries: entries, err: err}
+	}
+}
 
-		header := []string{"WatchDate", "Title", "Year", "Rating", "Rewatch"}
-		if err := writer.Write(header); err != nil {
-			return exportDiaryResultMsg{err: fmt.Errorf("failed to write CSV header: %w", err)}
-		}
-		for _, entry := range entries {
-			rewatchStr := ""
-			if entry.Rewatch {
-				rewatchStr = "Yes"
-			}
-			row := []string{
-				entry.WatchDate,
-				entry.Title,
-				fmt.Sprintf("%d", entry.Year),
-				fmt.Sprintf("%.1f", entry.Rating),
-				rewatchStr,
-			}
-			if err := writer.Write(row); err != nil {
-				fmt.Printf("Error writing diary row for %s: %v\n", entry.Title, err)
-			}
+func diaryTable(entries []DiaryEntry, username string) export.Table {
+	t := export.Table{
+		Title: fmt.Sprintf("%s's Diary", username),
+		Columns: []export.Column{
+			{Name: "WatchDate"},
+			{Name: "Title"},
+			{Name: "Year", Numeric: true},
+			{Name: "Rating", Numeric: true},
+			{Name: "Rewatch"},
+		},
+		DateColumn: "WatchDate",
+	}
+	for _, entry := range entries {
+		rewatchStr := ""
+		if entry.Rewatch {
+			rewatchStr = "Yes"
 		}
-		return exportDiaryResultMsg{filePath: filePath}
+		t.Rows = append(t.Rows, []string{
+			entry.WatchDate,
+			entry.Title,
+			fmt.Sprintf("%d", entry.Year),
+			fmt.Sprintf("%.1f", entry.Rating),
+			rewatchStr,
+		})
 	}
+	return t
 }
 
 func ratingToStars(rating float64) string {
@@ -295,33 +227,13 @@ func (m DiaryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
-	if m.promptingExportPath {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "ctrl+c", "q":
-				m.quitting = true
-				return m, tea.Quit
-			case "esc":
-				m.promptingExportPath = false
-				m.exportInput.Blur()
-				m.exportInput.Reset()
-				m.exportErr = nil
-				return m, nil
-			case "enter":
-				m.promptingExportPath = false
-				m.exportInput.Blur()
-				path := m.exportInput.Value()
-				m.exportInput.Reset()
-				m.exportPath = ""
-				m.exportErr = nil
-				cmds = append(cmds, exportDiaryToCSV(m.diaryEntries, m.targetUser, path))
-				return m, tea.Batch(cmds...)
-			}
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
 		}
-		m.exportInput, cmd = m.exportInput.Update(msg)
-		cmds = append(cmds, cmd)
-		return m, tea.Batch(cmds...)
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
 	}
 
 	switch msg := msg.(type) {
@@ -342,8 +254,7 @@ func (m DiaryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showDiary = false
 				m.submitted = false
 				m.input.Focus()
-				m.exportPath = ""
-				m.exportErr = nil
+				m.exportStatus = exportStatus{}
 				m.diaryEntries = nil
 				return m, nil
 			} else {
@@ -351,6 +262,9 @@ func (m DiaryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "enter":
 			if !m.submitted {
+				if links.IsLink(m.input.Value()) {
+					return openLink(m.input.Value(), m)
+				}
 				m.submitted = true
 				m.showSpinner = true
 				m.targetUser = m.input.Value()
@@ -358,12 +272,17 @@ func (m DiaryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "e":
 			if m.showDiary && len(m.diaryEntries) > 0 {
-				m.promptingExportPath = true
-				m.exportInput.Focus()
-				safeUsername := strings.ReplaceAll(strings.ReplaceAll(m.targetUser, "/", "_"), " ", "_")
-				defaultFileName := fmt.Sprintf("exports/diary_%s.csv", safeUsername)
-				m.exportInput.SetValue(defaultFileName)
-				return m, textinput.Blink
+				return m, m.exporter.Open(diaryTable(m.diaryEntries, m.targetUser), "exports/diary_"+safeFileName(m.targetUser))
+			}
+		case "v":
+			// Poll over the page being shown; rewatches are offered once.
+			if m.showDiary && len(m.diaryEntries) > 0 {
+				start, end := m.paginator.GetSliceBounds(len(m.diaryEntries))
+				var movies []Movie
+				for _, e := range m.diaryEntries[start:end] {
+					movies = append(movies, Movie{Title: e.Title, Year: e.Year, Slug: e.Slug})
+				}
+				return startPoll(fmt.Sprintf("What should we rewatch from @%s's diary?", m.targetUser), movies, m)
 			}
 		case "left", "h", "right", "l":
 			if !m.showDiary {
@@ -387,15 +306,13 @@ func (m DiaryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateTableRows()
 		}
 
-	case exportDiaryResultMsg:
-		m.exportErr = msg.err
-		m.exportPath = msg.filePath
-		m.lastExportMsg = time.Now()
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.table.SetWidth(msg.Width - 4)
-		m.exportInput.Width = msg.Width - 20
+		m.exporter.input.Width = msg.Width - 20
 	}
 
 	if m.showSpinner {
@@ -426,12 +343,8 @@ func (m DiaryModel) View() string {
 		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
 	}
 
-	if m.promptingExportPath {
-		return lipgloss.JoinVertical(lipgloss.Left,
-			fmt.Sprintf("Exporting diary for: %s", m.targetUser),
-			exportInputStyle.Render(m.exportInput.View()),
-			"\n(Enter path relative to current dir. Press Enter to confirm, Esc to cancel)",
-		)
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting diary for: %s", m.targetUser))
 	}
 
 	if m.showSpinner {
@@ -439,21 +352,14 @@ func (m DiaryModel) View() string {
 	}
 
 	if m.showDiary {
-		exportMsg := ""
-		if time.Since(m.lastExportMsg) < 5*time.Second {
-			if m.exportErr != nil {
-				exportMsg = exportStatusStyle.Render(fmt.Sprintf("Export failed: %v", m.exportErr))
-			} else if m.exportPath != "" {
-				exportMsg = exportStatusStyle.Render(fmt.Sprintf("Diary exported to %s", m.exportPath))
-			}
-		}
+		exportMsg := m.exportStatus.View("Diary")
 
 		tableRender := m.baseStyle.Render(m.table.View())
 
 		viewContent := lipgloss.JoinVertical(lipgloss.Left,
 			tableRender,
 			m.paginator.View(),
-			"\n(Use ←/→ to change page, 'e' to export, Esc to go back)",
+			"\n(Use ←/→ to change page, 'v' start a poll, 'e' to export, Esc to go back)",
 		)
 
 		if exportMsg != "" {
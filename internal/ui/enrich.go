@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/cache"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// enrichWorkers bounds how many film lookups run at once.
+	enrichWorkers = 6
+
+	// filmSummaryMaxAge is how long a cached summary is trusted. Ratings
+	// drift, but slowly.
+	filmSummaryMaxAge = 7 * 24 * time.Hour
+)
+
+var enrichBarEmptyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// filmSummary is the extra detail the enrichment pass adds to a row.
+type filmSummary struct {
+	Slug     string   `json:"slug"`
+	Director string   `json:"director"`
+	Runtime  int      `json:"runtime"`
+	Rating   float64  `json:"rating"`
+	Genres   []string `json:"genres"`
+
+	// Streaming is only fetched when asked for.
+	Streaming []string `json:"streaming,omitempty"`
+}
+
+// loadFilmSummary returns the summary for slug from the disk cache,
+// fetching it on a miss. Summaries with streaming services are cached
+// separately since they cost more to fetch.
+func loadFilmSummary(slug string, providers bool) (filmSummary, error) {
+	key, args := slug, []string{slug}
+	if providers {
+		key, args = slug+"+providers", append(args, "providers")
+	}
+
+	var s filmSummary
+	store, err := cache.Open("film-summaries", filmSummaryMaxAge)
+	if err == nil {
+		if data, ok := store.Get(key); ok && json.Unmarshal(data, &s) == nil {
+			return s, nil
+		}
+	}
+
+	out, err := runPyExec("get_film_summary", args...)
+	if err != nil {
+		return filmSummary{}, err
+	}
+	if err := json.Unmarshal(out, &s); err != nil {
+		return filmSummary{}, fmt.Errorf("failed to parse film summary JSON: %w", err)
+	}
+	if store != nil {
+		_ = store.Put(key, out)
+	}
+	return s, nil
+}
+
+type filmSummaryMsg struct {
+	run     int
+	slug    string
+	summary filmSummary
+	err     error
+}
+
+type enrichDoneMsg struct {
+	run int
+}
+
+// forEachBounded calls fn for every index below n, with at most
+// enrichWorkers calls running at once, and returns how many of them
+// failed. Calls for different indexes may write to their own slice
+// elements without locking.
+func forEachBounded(n int, fn func(i int) error) int {
+	jobs := make(chan int)
+	var failed atomic.Int64
+	var wg sync.WaitGroup
+	for range min(enrichWorkers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if fn(i) != nil {
+					failed.Add(1)
+				}
+			}
+		}()
+	}
+	for i := range n {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return int(failed.Load())
+}
+
+// enrichment is one pass over a set of films. Results arrive one at a
+// time through waitForSummary so the progress bar can follow along.
+type enrichment struct {
+	run     int
+	total   int
+	done    int
+	failed  int
+	running bool
+	results <-chan filmSummaryMsg
+	cancel  context.CancelFunc
+}
+
+// startEnrichment looks up slugs with at most enrichWorkers lookups in
+// flight, including streaming services if providers is set. run tags the
+// results so a cancelled pass can be told apart from the one that
+// replaced it.
+func startEnrichment(run int, slugs []string, providers bool) (enrichment, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan filmSummaryMsg)
+
+	go func() {
+		defer close(results)
+		forEachBounded(len(slugs), func(i int) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			s, err := loadFilmSummary(slugs[i], providers)
+			select {
+			case results <- filmSummaryMsg{run: run, slug: slugs[i], summary: s, err: err}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	e := enrichment{run: run, total: len(slugs), running: true, results: results, cancel: cancel}
+	return e, e.wait()
+}
+
+// wait returns the next result of the pass, or enrichDoneMsg once every
+// film has been looked up.
+func (e enrichment) wait() tea.Cmd {
+	results, run := e.results, e.run
+	return func() tea.Msg {
+		msg, ok := <-results
+		if !ok {
+			return enrichDoneMsg{run: run}
+		}
+		return msg
+	}
+}
+
+// stop abandons the pass. Finished lookups are already cached, so
+// starting again later only fetches what is left.
+func (e *enrichment) stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.running = false
+}
+
+func (e enrichment) View(width int) string {
+	if e.total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Fetching details %s %d/%d", progressBar(e.done, e.total, width), e.done, e.total)
+}
+
+// progressBar draws done out of total as a bar width cells long.
+func progressBar(done, total, width int) string {
+	filled := min(done, total) * width / total
+	return histogramBarStyle.Render(strings.Repeat("█", filled)) +
+		enrichBarEmptyStyle.Render(strings.Repeat("░", width-filled))
+}
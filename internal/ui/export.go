@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var exportFormatStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("242"))
+
+type exportResultMsg struct {
+	filePath string
+	err      error
+}
+
+// exportPrompt is the path/format prompt shared by every screen that can
+// export its data. It exports either a table or, when doc is set, a
+// document.
+type exportPrompt struct {
+	input   textinput.Model
+	active  bool
+	table   export.Table
+	doc     *export.Document
+	formats []export.Exporter
+	format  int
+
+	// confirmPath is set while asking whether to overwrite an existing
+	// file at that (expanded) path.
+	confirmPath string
+	errMsg      string
+}
+
+func newExportPrompt(placeholder string, promptStyle, cursorStyle, textStyle lipgloss.Style) exportPrompt {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 256
+	ti.Width = 60
+	ti.Prompt = "Export Path: "
+	ti.PromptStyle = promptStyle.Copy()
+	ti.Cursor.Style = cursorStyle.Copy()
+	ti.TextStyle = textStyle.Copy()
+	return exportPrompt{input: ti}
+}
+
+// Open starts prompting for an export of t. baseName is the suggested file
+// name without extension, e.g. "exports/diary_alice".
+func (p *exportPrompt) Open(t export.Table, baseName string) tea.Cmd {
+	p.table = t
+	p.doc = nil
+	return p.open(export.Formats(t), baseName)
+}
+
+// OpenDocument starts prompting for an export of d.
+func (p *exportPrompt) OpenDocument(d export.Document, baseName string) tea.Cmd {
+	p.table = export.Table{}
+	p.doc = &d
+	return p.open(export.DocumentFormats(), baseName)
+}
+
+func (p *exportPrompt) open(formats []export.Exporter, baseName string) tea.Cmd {
+	p.active = true
+	p.formats = formats
+	p.format = 0
+	p.input.SetValue(baseName + p.formats[p.format].Extension())
+	p.input.CursorEnd()
+	p.input.Focus()
+	return textinput.Blink
+}
+
+func (p *exportPrompt) close() {
+	p.active = false
+	p.confirmPath = ""
+	p.errMsg = ""
+	p.input.Blur()
+	p.input.Reset()
+}
+
+// selected returns the exporter for the current path, preferring the one
+// implied by the typed extension over the cycled format.
+func (p exportPrompt) selected() export.Exporter {
+	if e, ok := export.ForPath(p.input.Value()); ok {
+		for _, allowed := range p.formats {
+			if allowed.Name() == e.Name() {
+				return e
+			}
+		}
+	}
+	return p.formats[p.format]
+}
+
+// cycleFormat moves to the next supported format and swaps the extension of
+// the typed path to match.
+func (p *exportPrompt) cycleFormat(step int) {
+	current := p.selected()
+	for i, e := range p.formats {
+		if e.Name() == current.Name() {
+			p.format = i
+		}
+	}
+	p.format = (p.format + step + len(p.formats)) % len(p.formats)
+
+	path := p.input.Value()
+	if _, ok := export.ForPath(path); ok {
+		path = strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	p.input.SetValue(path + p.formats[p.format].Extension())
+	p.input.CursorEnd()
+}
+
+// submit starts the export, or asks for confirmation first when the
+// destination already exists and overwrite is not set.
+func (p *exportPrompt) submit(path string, overwrite bool) tea.Cmd {
+	filePath, err := export.ExpandPath(path)
+	if err != nil {
+		p.errMsg = err.Error()
+		return nil
+	}
+	if !overwrite && export.Exists(filePath) {
+		p.confirmPath = filePath
+		return nil
+	}
+
+	exporter := p.selected()
+	table, doc := p.table, p.doc
+	p.close()
+	if doc != nil {
+		return exportDocument(*doc, exporter, filePath, overwrite)
+	}
+	return exportTable(table, exporter, filePath, overwrite)
+}
+
+func (p exportPrompt) Update(msg tea.Msg) (exportPrompt, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && p.confirmPath != "" {
+		switch msg.String() {
+		case "o", "y":
+			return p, p.submit(p.confirmPath, true)
+		case "n":
+			return p, p.submit(export.NextFreePath(p.confirmPath), false)
+		case "esc":
+			p.confirmPath = ""
+		}
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		p.errMsg = ""
+		switch msg.String() {
+		case "esc":
+			p.close()
+			return p, nil
+		case "tab":
+			p.cycleFormat(1)
+			return p, nil
+		case "shift+tab":
+			p.cycleFormat(-1)
+			return p, nil
+		case "enter":
+			return p, p.submit(p.input.Value(), false)
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p exportPrompt) View(heading string) string {
+	var names []string
+	current := p.selected()
+	for _, e := range p.formats {
+		if e.Name() == current.Name() {
+			names = append(names, navActiveStyle.Render(e.Name()))
+		} else {
+			names = append(names, navStyle.Render(e.Name()))
+		}
+	}
+
+	if p.confirmPath != "" {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			heading,
+			exportStatusStyle.Render(fmt.Sprintf("%s already exists.", p.confirmPath)),
+			fmt.Sprintf("\n(o to overwrite, n to save as %s, Esc to edit the path)", filepath.Base(export.NextFreePath(p.confirmPath))),
+		)
+	}
+
+	lines := []string{
+		heading,
+		exportInputStyle.Render(p.input.View()),
+		exportFormatStyle.Render("Format: ") + strings.Join(names, " "),
+	}
+	if p.errMsg != "" {
+		lines = append(lines, exportStatusStyle.Render(p.errMsg))
+	}
+	lines = append(lines, "\n(Relative, absolute or ~/ paths. Tab to change format, Enter to confirm, Esc to cancel)")
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func exportTable(t export.Table, e export.Exporter, path string, overwrite bool) tea.Cmd {
+	return func() tea.Msg {
+		filePath, err := export.WriteFile(path, e, t, overwrite)
+		return exportResultMsg{filePath: filePath, err: err}
+	}
+}
+
+func exportDocument(d export.Document, e export.Exporter, path string, overwrite bool) tea.Cmd {
+	return func() tea.Msg {
+		filePath, err := export.WriteDocumentFile(path, e, d, overwrite)
+		return exportResultMsg{filePath: filePath, err: err}
+	}
+}
+
+// exportStatus remembers the outcome of the last export so screens can
+// show it for a few seconds.
+type exportStatus struct {
+	filePath string
+	err      error
+	at       time.Time
+}
+
+func newExportStatus(msg exportResultMsg) exportStatus {
+	return exportStatus{filePath: msg.filePath, err: msg.err, at: time.Now()}
+}
+
+// View renders the status line, or "" once it has expired. noun names what
+// was exported, e.g. "Diary".
+func (s exportStatus) View(noun string) string {
+	if s.at.IsZero() || time.Since(s.at) >= 5*time.Second {
+		return ""
+	}
+
+	var rowErrs export.RowErrors
+	switch {
+	case errors.As(s.err, &rowErrs):
+		return exportStatusStyle.Render(fmt.Sprintf("%s exported to %s, but %d row(s) were skipped: %v", noun, s.filePath, len(rowErrs), s.err))
+	case s.err != nil:
+		return exportStatusStyle.Render(fmt.Sprintf("Export failed: %v", s.err))
+	case s.filePath != "":
+		return exportStatusStyle.Render(fmt.Sprintf("%s exported to %s", noun, s.filePath))
+	}
+	return ""
+}
+
+// safeFileName makes s usable as part of a file name.
+func safeFileName(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "/", "_"), " ", "_")
+}
@@ -0,0 +1,459 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	filmsTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00A86B")).
+			Bold(true).
+			Margin(1, 2, 0, 2)
+
+	filmsFilterStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("242")).
+				Margin(0, 2, 1, 2)
+)
+
+// LoggedFilm is a film on a user's films page along with what they did
+// with it.
+type LoggedFilm struct {
+	Title    string  `json:"title"`
+	Year     int     `json:"year"`
+	Slug     string  `json:"slug"`
+	Rating   float64 `json:"rating"`
+	Liked    bool    `json:"liked"`
+	Reviewed bool    `json:"reviewed"`
+}
+
+type filmsPage struct {
+	Films   []LoggedFilm `json:"films"`
+	Page    int          `json:"page"`
+	HasMore bool         `json:"has_more"`
+}
+
+// filmsFilter selects and orders a user's films. Empty fields mean no
+// filter.
+type filmsFilter struct {
+	Sort   string
+	Decade string
+	Genre  string
+	Rated  string
+}
+
+var (
+	filmsSorts      = []string{"rated", "rating", "release", "popularity"}
+	filmsSortLabels = map[string]string{
+		"rated":      "when rated",
+		"rating":     "rating",
+		"release":    "release date",
+		"popularity": "popularity",
+	}
+	filmsDecades = []string{"", "2020s", "2010s", "2000s", "1990s", "1980s", "1970s",
+		"1960s", "1950s", "1940s", "1930s", "1920s"}
+	filmsGenres = []string{"", "action", "adventure", "animation", "comedy", "crime",
+		"documentary", "drama", "family", "fantasy", "history", "horror", "music",
+		"mystery", "romance", "science-fiction", "thriller", "tv-movie", "war", "western"}
+	filmsRated = []string{"", "rated", "unrated"}
+)
+
+// nextOption returns the option after current, wrapping around.
+func nextOption(options []string, current string) string {
+	for i, o := range options {
+		if o == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+func (f filmsFilter) String() string {
+	parts := []string{"sorted by " + filmsSortLabels[f.Sort]}
+	if f.Decade != "" {
+		parts = append(parts, f.Decade)
+	}
+	if f.Genre != "" {
+		parts = append(parts, roleLabel(f.Genre))
+	}
+	if f.Rated != "" {
+		parts = append(parts, f.Rated)
+	}
+	return strings.Join(parts, " · ")
+}
+
+type filmsResultMsg struct {
+	filter filmsFilter
+	page   int
+	result filmsPage
+	err    error
+}
+
+func callPythonGetUserFilms(username string, f filmsFilter, page int) (filmsPage, error) {
+	out, err := runPyExec("get_user_films", username, f.Sort, strconv.Itoa(page), f.Decade, f.Genre, f.Rated)
+	if err != nil {
+		return filmsPage{}, err
+	}
+	var result filmsPage
+	if err := json.Unmarshal(out, &result); err != nil {
+		return filmsPage{}, fmt.Errorf("failed to parse films JSON: %w", err)
+	}
+	return result, nil
+}
+
+// loadLoggedFilms pages through every film username has logged.
+func loadLoggedFilms(username string) ([]LoggedFilm, error) {
+	f := filmsFilter{Sort: filmsSorts[0]}
+	films := []LoggedFilm{}
+	for page := 1; ; page++ {
+		result, err := callPythonGetUserFilms(username, f, page)
+		if err != nil {
+			return nil, err
+		}
+		films = append(films, result.Films...)
+		if !result.HasMore || len(result.Films) == 0 {
+			return films, nil
+		}
+	}
+}
+
+func fetchUserFilms(username string, f filmsFilter, page int) tea.Cmd {
+	return func() tea.Msg {
+		result, err := callPythonGetUserFilms(username, f, page)
+		return filmsResultMsg{filter: f, page: page, result: result, err: err}
+	}
+}
+
+// FilmsModel pages through every film a user has logged. Esc returns to
+// the screen it was opened from.
+type FilmsModel struct {
+	username      string
+	filter        filmsFilter
+	spinner       spinner.Model
+	table         table.Model
+	films         []LoggedFilm
+	page          int
+	hasMore       bool
+	loading       bool
+	loadingMore   bool
+	exportQueued  bool // load every page, then open the export prompt
+	exportPartial bool // a page failed, so the export lacks some films
+	quitting      bool
+	err           error
+	back          tea.Model
+	exporter      exportPrompt
+	exportStatus  exportStatus
+	baseStyle     lipgloss.Style
+	width         int
+}
+
+func NewFilmsModel(username string, back tea.Model) FilmsModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	columns := []table.Column{
+		{Title: "Title", Width: 40},
+		{Title: "Year", Width: 6},
+		{Title: "Rating", Width: 8},
+		{Title: "Like", Width: 4},
+		{Title: "Review", Width: 6},
+	}
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	exporter := newExportPrompt("e.g., exports/films.csv",
+		searchInputPromptStyle, searchInputCursorStyle, searchInputTextStyle)
+
+	return FilmsModel{
+		username:  username,
+		filter:    filmsFilter{Sort: filmsSorts[0]},
+		spinner:   sp,
+		table:     t,
+		loading:   true,
+		page:      1,
+		back:      back,
+		exporter:  exporter,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+	}
+}
+
+func (m FilmsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, fetchUserFilms(m.username, m.filter, 1))
+}
+
+// reload starts over from the first page after the filter changed.
+func (m *FilmsModel) reload() tea.Cmd {
+	m.loading = true
+	m.loadingMore = false
+	m.exportQueued = false
+	m.err = nil
+	m.films = nil
+	m.page = 1
+	m.table.SetRows(nil)
+	m.table.SetCursor(0)
+	return tea.Batch(m.spinner.Tick, fetchUserFilms(m.username, m.filter, 1))
+}
+
+// openExport opens the export prompt on the films loaded so far; complete
+// says whether that is all of them.
+func (m *FilmsModel) openExport(complete bool) tea.Cmd {
+	m.exportQueued = false
+	m.exportPartial = !complete
+	baseName := "exports/films_" + safeFileName(m.username)
+	return m.exporter.Open(userFilmsTable(m.username, m.filter, m.films, complete), baseName)
+}
+
+func (m FilmsModel) goBack() (tea.Model, tea.Cmd) {
+	if m.back != nil {
+		return m.back, nil
+	}
+	return NewMenuModel(), nil
+}
+
+// userFilmsTable describes films for export. complete is false when not
+// every page could be loaded, which the title then says.
+func userFilmsTable(username string, f filmsFilter, films []LoggedFilm, complete bool) export.Table {
+	title := fmt.Sprintf("@%s's films (%s)", username, f)
+	if !complete {
+		title += fmt.Sprintf(" (partial: first %d films)", len(films))
+	}
+	t := export.Table{
+		Title: title,
+		Columns: []export.Column{
+			{Name: "Title"},
+			{Name: "Year", Numeric: true},
+			{Name: "Rating", Numeric: true},
+			{Name: "Liked"},
+			{Name: "Reviewed"},
+			{Name: "Slug"},
+		},
+	}
+	for _, film := range films {
+		rating := ""
+		if film.Rating > 0 {
+			rating = fmt.Sprintf("%.1f", film.Rating)
+		}
+		t.Rows = append(t.Rows, []string{
+			film.Title,
+			fmt.Sprintf("%d", film.Year),
+			rating,
+			strconv.FormatBool(film.Liked),
+			strconv.FormatBool(film.Reviewed),
+			film.Slug,
+		})
+	}
+	return t
+}
+
+func (m *FilmsModel) updateRows() {
+	rows := make([]table.Row, len(m.films))
+	for i, film := range m.films {
+		year, rating, liked, reviewed := "", "–", "", ""
+		if film.Year > 0 {
+			year = fmt.Sprintf("%d", film.Year)
+		}
+		if film.Rating > 0 {
+			rating = starLabel(film.Rating)
+		}
+		if film.Liked {
+			liked = "♥"
+		}
+		if film.Reviewed {
+			reviewed = "✎"
+		}
+		rows[i] = table.Row{film.Title, year, rating, liked, reviewed}
+	}
+	m.table.SetRows(rows)
+}
+
+func (m FilmsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			return m.goBack()
+
+		case "enter":
+			cursor := m.table.Cursor()
+			if !m.loading && cursor < len(m.films) {
+				film := m.films[cursor]
+				return newFilmDetailsModel(Movie{Title: film.Title, Year: film.Year, Slug: film.Slug}, m)
+			}
+
+		case "s":
+			m.filter.Sort = nextOption(filmsSorts, m.filter.Sort)
+			return m, m.reload()
+
+		case "d":
+			m.filter.Decade = nextOption(filmsDecades, m.filter.Decade)
+			return m, m.reload()
+
+		case "g":
+			m.filter.Genre = nextOption(filmsGenres, m.filter.Genre)
+			return m, m.reload()
+
+		case "r":
+			m.filter.Rated = nextOption(filmsRated, m.filter.Rated)
+			return m, m.reload()
+
+		case "v":
+			if !m.loading && len(m.films) > 0 {
+				movies := make([]Movie, len(m.films))
+				for i, f := range m.films {
+					movies[i] = Movie{Title: f.Title, Year: f.Year, Slug: f.Slug}
+				}
+				return startPoll(fmt.Sprintf("What should we rewatch from @%s's films?", m.username), movies, m)
+			}
+
+		case "e":
+			if !m.loading && len(m.films) > 0 && !m.exportQueued {
+				// The table only holds the pages scrolled through so far.
+				if !m.hasMore {
+					return m, m.openExport(true)
+				}
+				m.exportQueued = true
+				if !m.loadingMore {
+					m.loadingMore = true
+					return m, fetchUserFilms(m.username, m.filter, m.page+1)
+				}
+				return m, nil
+			}
+		}
+
+	case filmsResultMsg:
+		// Drop pages for a filter that has since been changed.
+		if msg.filter != m.filter {
+			return m, nil
+		}
+		m.loading = false
+		m.loadingMore = false
+		if msg.err != nil {
+			if len(m.films) == 0 {
+				m.err = msg.err
+			}
+			m.hasMore = false
+			if m.exportQueued && len(m.films) > 0 {
+				return m, m.openExport(false)
+			}
+			m.exportQueued = false
+			return m, nil
+		}
+		m.page = msg.page
+		m.hasMore = msg.result.HasMore
+		m.films = append(m.films, msg.result.Films...)
+		m.updateRows()
+		if m.exportQueued {
+			if m.hasMore {
+				m.loadingMore = true
+				return m, fetchUserFilms(m.username, m.filter, m.page+1)
+			}
+			return m, m.openExport(true)
+		}
+		return m, nil
+
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.table.SetWidth(msg.Width - 4)
+		m.exporter.input.Width = msg.Width - 20
+	}
+
+	if m.loading {
+		m.spinner, cmd = m.spinner.Update(msg)
+		cmds = append(cmds, cmd)
+	} else {
+		m.table, cmd = m.table.Update(msg)
+		cmds = append(cmds, cmd)
+
+		if m.table.Cursor() >= len(m.films)-3 && m.hasMore && !m.loadingMore {
+			m.loadingMore = true
+			cmds = append(cmds, fetchUserFilms(m.username, m.filter, m.page+1))
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m FilmsModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting films: @%s", m.username))
+	}
+	if m.loading {
+		return fmt.Sprintf("\n\n   %s Fetching @%s's films (%s)...\n\n", m.spinner.View(), m.username, m.filter)
+	}
+
+	header := lipgloss.JoinVertical(lipgloss.Left,
+		filmsTitleStyle.Render(fmt.Sprintf("@%s's films", m.username)),
+		filmsFilterStyle.Render(m.filter.String()),
+	)
+	help := "(Enter to view film, 's' sort, 'd' decade, 'g' genre, 'r' rated/unrated, 'v' start a poll, 'e' to export, Esc to go back)"
+
+	if m.err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, header, fmt.Sprintf("Error: %v", m.err), "", help)
+	}
+	if len(m.films) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, "No films match these filters.", "", help)
+	}
+
+	status := fmt.Sprintf("%d films loaded", len(m.films))
+	switch {
+	case m.exportQueued:
+		status += fmt.Sprintf(", loading page %d of the rest before exporting...", m.page+1)
+	case m.loadingMore:
+		status += fmt.Sprintf(", loading page %d...", m.page+1)
+	case !m.hasMore:
+		status += ", end of list"
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		m.baseStyle.Render(m.table.View()),
+		status,
+		help,
+	)
+	noun := "Films"
+	if m.exportPartial {
+		noun = fmt.Sprintf("Partial films list (first %d)", len(m.films))
+	}
+	if exportMsg := m.exportStatus.View(noun); exportMsg != "" {
+		view += "\n" + exportMsg
+	}
+	return view
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	histogramBarStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#00A86B"))
+
+	histogramLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFD700")).
+				Width(7)
+
+	histogramCountStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("242"))
+)
+
+// RatingBucket is the number of ratings given at one half-star step.
+type RatingBucket struct {
+	Rating float64 `json:"rating"`
+	Count  int     `json:"count"`
+}
+
+// starLabel renders a rating out of 5 the way Letterboxd does, e.g. 3.5
+// becomes "★★★½".
+func starLabel(rating float64) string {
+	halves := int(rating*2 + 0.5)
+	label := strings.Repeat("★", halves/2)
+	if halves%2 == 1 {
+		label += "½"
+	}
+	return label
+}
+
+func ratingCount(histogram []RatingBucket) int {
+	total := 0
+	for _, b := range histogram {
+		total += b.Count
+	}
+	return total
+}
+
+func (m SearchModel) histogramWidth() int {
+	if m.width == 0 {
+		return 40
+	}
+	if w := m.width - 40; w > 10 {
+		if w > 60 {
+			return 60
+		}
+		return w
+	}
+	return 10
+}
+
+// renderHistogram draws one horizontal bar per half-star step, scaled so
+// the most common rating fills the available width.
+func (m SearchModel) renderHistogram() string {
+	histogram := m.movieDetails.Histogram
+	total := ratingCount(histogram)
+	if total == 0 {
+		return movieSubtitleStyle.Render("No ratings yet.")
+	}
+
+	most := 0
+	for _, b := range histogram {
+		if b.Count > most {
+			most = b.Count
+		}
+	}
+
+	width := m.histogramWidth()
+	var lines []string
+	for i := len(histogram) - 1; i >= 0; i-- {
+		b := histogram[i]
+		length := b.Count * width / most
+		if length == 0 && b.Count > 0 {
+			length = 1
+		}
+		bar := histogramBarStyle.Render(strings.Repeat("█", length)) + strings.Repeat(" ", width-length)
+		count := histogramCountStyle.Render(fmt.Sprintf("%6s  %4.1f%%",
+			formatLargeNumber(b.Count), float64(b.Count)*100/float64(total)))
+		lines = append(lines, histogramLabelStyle.Render(starLabel(b.Rating))+bar+"  "+count)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m SearchModel) renderFilmStats() string {
+	d := m.movieDetails
+
+	stat := func(value int, label string, style lipgloss.Style) string {
+		return lipgloss.JoinVertical(lipgloss.Center,
+			style.Render(formatLargeNumber(value)),
+			movieStatLabelStyle.Render(label),
+		)
+	}
+	numbers := lipgloss.JoinHorizontal(lipgloss.Top,
+		stat(d.Members, "watched", movieStatNumberGreenStyle),
+		"   ",
+		stat(d.Fans, "fans", movieStatNumberOrangeStyle),
+		"   ",
+		stat(d.Likes, "likes", movieStatNumberGreenStyle),
+		"   ",
+		stat(d.ReviewCount, "reviews", movieStatNumberBlueStyle),
+		"   ",
+		stat(d.Lists, "lists", movieStatNumberOrangeStyle),
+	)
+
+	ratingHeader := synopsisHeaderStyle.Render("ratings")
+	if total := ratingCount(d.Histogram); total > 0 {
+		ratingHeader += movieSubtitleStyle.Render(fmt.Sprintf("  %s ratings · average ", formatLargeNumber(total))) +
+			movieRatingStyle.Render(fmt.Sprintf("★ %.1f/5", d.Rating))
+	}
+
+	return docStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		statContainerStyle.Render(numbers),
+		ratingHeader,
+		"",
+		m.renderHistogram(),
+	))
+}
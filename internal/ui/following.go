@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/store"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// followedList is the snapshot kept for a list the user follows locally:
+// the films it had when it was last opened.
+type followedList struct {
+	List      ListSearchResult `json:"list"`
+	CheckedAt time.Time        `json:"checked_at"`
+	Films     []Movie          `json:"films"`
+}
+
+// listChanges is how a followed list differs from its snapshot.
+type listChanges struct {
+	Since   time.Time
+	Added   []Movie
+	Removed []Movie
+}
+
+type followedListsMsg struct {
+	lists []followedList
+	err   error
+}
+
+func followingStore() (*store.Store, error) {
+	return store.Open("following")
+}
+
+func followKey(l ListSearchResult) string {
+	return l.Owner + "/" + l.Slug
+}
+
+// filmKey identifies a film across snapshots, falling back to the title
+// and year for entries scraped without a slug.
+func filmKey(m Movie) string {
+	if m.Slug != "" {
+		return m.Slug
+	}
+	return fmt.Sprintf("%s (%d)", strings.ToLower(m.Title), m.Year)
+}
+
+// diffFilms returns the films in current but not in previous, and those
+// in previous but no longer in current, each in their list order.
+func diffFilms(previous, current []Movie) (added, removed []Movie) {
+	before := make(map[string]bool, len(previous))
+	for _, m := range previous {
+		before[filmKey(m)] = true
+	}
+	now := make(map[string]bool, len(current))
+	for _, m := range current {
+		now[filmKey(m)] = true
+		if !before[filmKey(m)] {
+			added = append(added, m)
+		}
+	}
+	for _, m := range previous {
+		if !now[filmKey(m)] {
+			removed = append(removed, m)
+		}
+	}
+	return added, removed
+}
+
+// checkFollowed compares a freshly fetched list with its snapshot, if the
+// list is followed, and replaces the snapshot with the current films. It
+// returns nil changes for lists that are not followed, and the list with
+// its name filled in from the snapshot when it was opened by reference.
+func checkFollowed(l ListSearchResult, movies []Movie) (ListSearchResult, *listChanges, error) {
+	s, err := followingStore()
+	if err != nil {
+		return l, nil, err
+	}
+	var snap followedList
+	found, err := s.Load(followKey(l), &snap)
+	if err != nil || !found {
+		return l, nil, err
+	}
+
+	changes := &listChanges{Since: snap.CheckedAt}
+	changes.Added, changes.Removed = diffFilms(snap.Films, movies)
+
+	// Keep the name we already know if the list was opened by reference.
+	if l.Name == followKey(l) {
+		l.Name = snap.List.Name
+	}
+	l.Films = len(movies)
+	err = s.Save(followKey(l), followedList{List: l, CheckedAt: time.Now(), Films: movies})
+	return l, changes, err
+}
+
+func followList(l ListSearchResult, movies []Movie) error {
+	s, err := followingStore()
+	if err != nil {
+		return err
+	}
+	l.Films = len(movies)
+	return s.Save(followKey(l), followedList{List: l, CheckedAt: time.Now(), Films: movies})
+}
+
+func unfollowList(l ListSearchResult) error {
+	s, err := followingStore()
+	if err != nil {
+		return err
+	}
+	return s.Delete(followKey(l))
+}
+
+func loadFollowedLists() tea.Msg {
+	s, err := followingStore()
+	if err != nil {
+		return followedListsMsg{err: err}
+	}
+	keys, err := s.Keys()
+	if err != nil {
+		return followedListsMsg{err: err}
+	}
+	var lists []followedList
+	for _, key := range keys {
+		var l followedList
+		if found, err := s.Load(key, &l); err == nil && found {
+			lists = append(lists, l)
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return strings.ToLower(lists[i].List.Name) < strings.ToLower(lists[j].List.Name)
+	})
+	return followedListsMsg{lists: lists}
+}
+
+// summary describes the changes in one line.
+func (c *listChanges) summary() string {
+	since := c.Since.Format("2 Jan 2006 15:04")
+	if len(c.Added) == 0 && len(c.Removed) == 0 {
+		return fmt.Sprintf("No changes since you last looked (%s).", since)
+	}
+	return fmt.Sprintf("Since you last looked (%s): %d added, %d removed.", since, len(c.Added), len(c.Removed))
+}
+
+func (c *listChanges) isAdded(m Movie) bool {
+	for _, a := range c.Added {
+		if filmKey(a) == filmKey(m) {
+			return true
+		}
+	}
+	return false
+}
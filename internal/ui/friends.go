@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/cache"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	followingMaxAge = 6 * time.Hour
+
+	// maxFriendLookups bounds how many of the people the user follows
+	// are looked up for a film's Friends tab.
+	maxFriendLookups = 200
+
+	// friendLookupBatch is how many people one run of get_user_film
+	// looks a film up for.
+	friendLookupBatch = 25
+)
+
+type friendRatingsMsg struct {
+	slug      string
+	ratings   []memberFilm
+	following int
+	polled    int
+	failed    int
+	err       error
+}
+
+// loadFollowing returns everyone username follows, from the disk cache
+// when it is recent enough.
+func loadFollowing(username string) ([]Friend, error) {
+	key := strings.ToLower(username)
+	store, err := cache.Open("following", followingMaxAge)
+	if err == nil {
+		var people []Friend
+		if data, ok := store.Get(key); ok && json.Unmarshal(data, &people) == nil {
+			return people, nil
+		}
+	}
+
+	people, err := loadFollows(username, "following")
+	if err != nil {
+		return nil, err
+	}
+	if store != nil {
+		if data, err := json.Marshal(people); err == nil {
+			_ = store.Put(key, data)
+		}
+	}
+	return people, nil
+}
+
+// fetchFriendRatings looks up the film slug for everyone username
+// follows, a batch of people per run of the script and a few runs at a
+// time, and keeps the ones who have seen it: the highest rated first,
+// then those who logged it without a rating.
+func fetchFriendRatings(username, slug string) tea.Cmd {
+	return func() tea.Msg {
+		msg := friendRatingsMsg{slug: slug}
+		following, err := loadFollowing(username)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.following = len(following)
+		polled := following[:min(len(following), maxFriendLookups)]
+		msg.polled = len(polled)
+
+		batches := (len(polled) + friendLookupBatch - 1) / friendLookupBatch
+		watched := make([][]memberFilm, batches)
+		failed := make([]int, batches)
+		forEachBounded(batches, func(b int) error {
+			people := polled[b*friendLookupBatch : min(len(polled), (b+1)*friendLookupBatch)]
+			usernames := make([]string, len(people))
+			for i, f := range people {
+				usernames[i] = f.Username
+			}
+			ratings, err := loadMemberFilms(usernames, slug)
+			if err != nil {
+				failed[b] = len(people)
+				return err
+			}
+			for i, r := range ratings {
+				switch {
+				case r.Error != "":
+					failed[b]++
+				case r.Watched:
+					r.Name = people[i].Name
+					watched[b] = append(watched[b], r)
+				}
+			}
+			return nil
+		})
+		for b := range batches {
+			msg.failed += failed[b]
+			msg.ratings = append(msg.ratings, watched[b]...)
+		}
+
+		if len(polled) > 0 && msg.failed == len(polled) {
+			msg.err = fmt.Errorf("could not look the film up for any of the %d people @%s follows", len(polled), username)
+			return msg
+		}
+		sort.Slice(msg.ratings, func(i, j int) bool {
+			a, b := msg.ratings[i], msg.ratings[j]
+			if a.Rating != b.Rating {
+				return a.Rating > b.Rating
+			}
+			return strings.ToLower(a.displayName()) < strings.ToLower(b.displayName())
+		})
+		return msg
+	}
+}
+
+// friendsAverage is the mean of the friends' ratings, leaving out those
+// who logged the film without rating it.
+func friendsAverage(ratings []memberFilm) (float64, int) {
+	var sum float64
+	n := 0
+	for _, r := range ratings {
+		if r.Rating > 0 {
+			sum += r.Rating
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return sum / float64(n), n
+}
+
+// loadFriends starts looking up the current film for the people the
+// configured user follows.
+func (m *SearchModel) loadFriends() tea.Cmd {
+	m.friendsSlug = m.selectedMovie.Slug
+	m.friendRatings = nil
+	m.friendsErr = nil
+	m.friendCursor = 0
+	username := appConfig().Username
+	if username == "" {
+		return nil
+	}
+	m.loadingFriends = true
+	return fetchFriendRatings(username, m.selectedMovie.Slug)
+}
+
+func (m *SearchModel) moveFriendCursor(key string) {
+	switch key {
+	case "up", "k":
+		m.friendCursor--
+	case "down", "j":
+		m.friendCursor++
+	case "pgup", "home":
+		m.friendCursor = 0
+	case "pgdown", "end":
+		m.friendCursor = len(m.friendRatings) - 1
+	}
+	if m.friendCursor >= len(m.friendRatings) {
+		m.friendCursor = len(m.friendRatings) - 1
+	}
+	if m.friendCursor < 0 {
+		m.friendCursor = 0
+	}
+}
+
+// openSelectedFriendReview opens the selected friend's review in the
+// reader.
+func (m *SearchModel) openSelectedFriendReview() {
+	if m.friendCursor >= len(m.friendRatings) || m.friendRatings[m.friendCursor].Review == "" {
+		return
+	}
+	r := m.friendRatings[m.friendCursor]
+	m.openReader(memberHeader(r), r.Review, r.URL)
+}
+
+// friendsSummary compares the friends' average with everyone's.
+func friendsSummary(ratings []memberFilm, global float64) string {
+	avg, n := friendsAverage(ratings)
+	if n == 0 {
+		return fmt.Sprintf("No ratings from friends yet · everyone ★ %.1f/5", global)
+	}
+	plural := "s"
+	if n == 1 {
+		plural = ""
+	}
+	line := fmt.Sprintf("Friends ★ %.1f/5 from %d rating%s · everyone ★ %.1f/5", avg, n, plural, global)
+	switch diff := avg - global; {
+	case global == 0:
+	case diff >= 0.05:
+		line += fmt.Sprintf(" · %.1f higher", diff)
+	case diff <= -0.05:
+		line += fmt.Sprintf(" · %.1f lower", -diff)
+	default:
+		line += " · the same"
+	}
+	return line
+}
+
+func (m SearchModel) renderFriendsTab() string {
+	if appConfig().Username == "" {
+		return "Set \"username\" in your config file, or LETTERCLI_USERNAME, to see how the people you follow rated this film."
+	}
+	if m.loadingFriends {
+		return fmt.Sprintf("Asking the people @%s follows about this film...", appConfig().Username)
+	}
+	if m.friendsErr != nil {
+		return fmt.Sprintf("Could not load friends' ratings: %v", m.friendsErr)
+	}
+
+	summary := movieRatingStyle.Render(friendsSummary(m.friendRatings, m.movieDetails.Rating))
+	coverage := fmt.Sprintf("%d of %d people you follow have logged it", len(m.friendRatings), m.friendsPolled)
+	if m.friendsPolled < m.friendsFollowing {
+		coverage += fmt.Sprintf(" (checked the first %d of %d)", m.friendsPolled, m.friendsFollowing)
+	}
+	if m.friendsFailed > 0 {
+		coverage += fmt.Sprintf(" · %d could not be checked", m.friendsFailed)
+	}
+	coverage = movieSubtitleStyle.Render(coverage)
+	if len(m.friendRatings) == 0 {
+		return summary + "\n" + coverage
+	}
+
+	// Each friend takes a header, a review preview and a blank line.
+	perScreen := (m.creditsHeight() - 2) / (reviewPreviewLines + 2)
+	if perScreen < 1 {
+		perScreen = 1
+	}
+	start := m.friendCursor - m.friendCursor%perScreen
+	end := min(start+perScreen, len(m.friendRatings))
+
+	blocks := []string{summary + "\n" + coverage}
+	for i := start; i < end; i++ {
+		r := m.friendRatings[i]
+		prefix := "  "
+		if i == m.friendCursor {
+			prefix = navActiveStyle.Render("→ ")
+		}
+		block := prefix + memberHeader(r)
+		if r.Review != "" {
+			block += "\n" + reviewPreviewStyle.Render(reviewPreview(r.Review, m.reviewWidth()-2))
+		}
+		blocks = append(blocks, block)
+	}
+	blocks = append(blocks, movieSubtitleStyle.Render(fmt.Sprintf("%d/%d", m.friendCursor+1, len(m.friendRatings))))
+	return strings.Join(blocks, "\n\n")
+}
@@ -0,0 +1,577 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// groupFilm is a film on at least one watchlist in the group.
+type groupFilm struct {
+	WatchlistFilm
+	On        []bool   // per member, in the order the names were given
+	SeenBy    []string // members who have logged it in their diary
+	Streaming []string
+}
+
+func (f groupFilm) count() int {
+	n := 0
+	for _, on := range f.On {
+		if on {
+			n++
+		}
+	}
+	return n
+}
+
+// groupFilter narrows the merged watchlist. MinCount 1 is the union of
+// the watchlists and the group's size their intersection.
+type groupFilter struct {
+	MinCount   int
+	HideSeen   bool
+	Runtime    string
+	Streaming  string
+	groupSize  int
+	streamable []string
+}
+
+func (f groupFilter) String() string {
+	var parts []string
+	switch f.MinCount {
+	case 1:
+		parts = append(parts, "on anyone's watchlist")
+	case f.groupSize:
+		parts = append(parts, "on everyone's watchlist")
+	default:
+		parts = append(parts, fmt.Sprintf("on at least %d watchlists", f.MinCount))
+	}
+	if f.HideSeen {
+		parts = append(parts, "unseen")
+	}
+	if f.Runtime != "" {
+		parts = append(parts, watchlistRuntimeLabels[f.Runtime])
+	}
+	if f.Streaming != "" {
+		parts = append(parts, "on "+f.Streaming)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (f groupFilter) keep(film groupFilm) bool {
+	if film.count() < f.MinCount {
+		return false
+	}
+	if f.HideSeen && len(film.SeenBy) > 0 {
+		return false
+	}
+	if !inRuntimeBand(f.Runtime, film.Runtime) {
+		return false
+	}
+	if f.Streaming != "" {
+		for _, s := range film.Streaming {
+			if s == f.Streaming {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+type groupWatchlistMsg struct {
+	users []string
+	films []groupFilm
+	err   error
+}
+
+// parseUsernames splits a list of names separated by commas or spaces,
+// dropping duplicates and any leading '@'.
+func parseUsernames(s string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		name := strings.TrimPrefix(f, "@")
+		if name != "" && !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// mergeWatchlists combines the members' watchlists, marking which films
+// each has already logged, and ranks films on more watchlists first.
+func mergeWatchlists(watchlists [][]WatchlistFilm, diaries [][]DiaryEntry, users []string) []groupFilm {
+	byKey := map[string]*groupFilm{}
+	var order []string
+	for i, films := range watchlists {
+		for _, f := range films {
+			key := filmKey(f.Movie)
+			g, ok := byKey[key]
+			if !ok {
+				g = &groupFilm{WatchlistFilm: f, On: make([]bool, len(users))}
+				byKey[key] = g
+				order = append(order, key)
+			}
+			g.On[i] = true
+		}
+	}
+	for i, entries := range diaries {
+		logged := map[string]bool{}
+		for _, e := range entries {
+			key := filmKey(Movie{Title: e.Title, Year: e.Year, Slug: e.Slug})
+			if g, ok := byKey[key]; ok && !logged[key] {
+				logged[key] = true
+				g.SeenBy = append(g.SeenBy, users[i])
+			}
+		}
+	}
+
+	films := make([]groupFilm, len(order))
+	for i, key := range order {
+		films[i] = *byKey[key]
+	}
+	sort.SliceStable(films, func(i, j int) bool {
+		return films[i].count() > films[j].count()
+	})
+	return films
+}
+
+// fetchGroupWatchlist fetches every member's watchlist and diary, a few
+// at a time.
+func fetchGroupWatchlist(users []string) tea.Cmd {
+	return func() tea.Msg {
+		watchlists := make([][]WatchlistFilm, len(users))
+		diaries := make([][]DiaryEntry, len(users))
+		errs := make([]error, 2*len(users))
+
+		forEachBounded(2*len(users), func(i int) error {
+			if i%2 == 0 {
+				watchlists[i/2], errs[i] = callPythonGetWatchlist(users[i/2])
+			} else {
+				diaries[i/2], errs[i] = loadDiary(users[i/2])
+			}
+			return errs[i]
+		})
+
+		for i, err := range errs {
+			if err != nil {
+				return groupWatchlistMsg{users: users, err: fmt.Errorf("%s: %w", users[i/2], err)}
+			}
+		}
+		return groupWatchlistMsg{users: users, films: mergeWatchlists(watchlists, diaries, users)}
+	}
+}
+
+func groupWatchlistTable(films []groupFilm, users []string) export.Table {
+	t := export.Table{
+		Title: "Group Watchlist: " + strings.Join(users, ", "),
+		Columns: []export.Column{
+			{Name: "Watchlists", Numeric: true},
+			{Name: "Title"},
+			{Name: "Year", Numeric: true},
+		},
+	}
+	for _, u := range users {
+		t.Columns = append(t.Columns, export.Column{Name: u})
+	}
+	t.Columns = append(t.Columns,
+		export.Column{Name: "Seen By"},
+		export.Column{Name: "Runtime", Numeric: true},
+		export.Column{Name: "Streaming"},
+	)
+	for _, f := range films {
+		row := []string{fmt.Sprintf("%d", f.count()), f.Title, fmt.Sprintf("%d", f.Year)}
+		for _, on := range f.On {
+			row = append(row, optionalNumber(on, "yes"))
+		}
+		row = append(row,
+			strings.Join(f.SeenBy, ", "),
+			optionalNumber(f.Runtime > 0, fmt.Sprintf("%d", f.Runtime)),
+			strings.Join(f.Streaming, ", "),
+		)
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// GroupWatchlistModel merges the watchlists of several people, for
+// picking something everyone wants to see.
+type GroupWatchlistModel struct {
+	input        textinput.Model
+	spinner      spinner.Model
+	table        table.Model
+	baseStyle    lipgloss.Style
+	loading      bool
+	showTable    bool
+	quitting     bool
+	err          error
+	users        []string
+	films        []groupFilm
+	visible      []groupFilm
+	filter       groupFilter
+	enrich       enrichment
+	enrichRuns   int
+	summaries    map[string]filmSummary
+	exporter     exportPrompt
+	exportStatus exportStatus
+}
+
+func NewGroupWatchlistModel() GroupWatchlistModel {
+	ti := textinput.New()
+	ti.Placeholder = "alice, bob, carol"
+	ti.Focus()
+	ti.CharLimit = 256
+	ti.Width = 50
+	ti.Prompt = "Usernames: "
+	ti.PromptStyle = watchInputPromptStyle
+	ti.Cursor.Style = watchInputCursorStyle
+	ti.TextStyle = watchInputTextStyle
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	t := table.New(table.WithFocused(true), table.WithHeight(15))
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	exporter := newExportPrompt("e.g., movie_night.csv or exports/group.csv",
+		watchInputPromptStyle, watchInputCursorStyle, watchInputTextStyle)
+
+	return GroupWatchlistModel{
+		input:     ti,
+		spinner:   sp,
+		table:     t,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+		summaries: map[string]filmSummary{},
+		exporter:  exporter,
+	}
+}
+
+func (m GroupWatchlistModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// applyFilter rebuilds the table from the films that pass the filter.
+func (m *GroupWatchlistModel) applyFilter() {
+	m.visible = m.visible[:0:0]
+	for _, f := range m.films {
+		if m.filter.keep(f) {
+			m.visible = append(m.visible, f)
+		}
+	}
+
+	columns := []table.Column{
+		{Title: "#", Width: 4},
+		{Title: "Title", Width: 36},
+		{Title: "Year", Width: 6},
+	}
+	for _, u := range m.users {
+		columns = append(columns, table.Column{Title: u, Width: min(len(u), 12)})
+	}
+	columns = append(columns, table.Column{Title: "Seen by", Width: 16})
+	runtimes, _, _ := watchlistColumns(watchlistFilms(m.visible))
+	if runtimes {
+		columns = append(columns, table.Column{Title: "Runtime", Width: 8})
+	}
+	if len(m.filter.streamable) > 0 {
+		columns = append(columns, table.Column{Title: "Streaming", Width: 24})
+	}
+
+	rows := make([]table.Row, len(m.visible))
+	for i, f := range m.visible {
+		row := table.Row{fmt.Sprintf("%d/%d", f.count(), len(m.users)), f.Title, fmt.Sprintf("%d", f.Year)}
+		for _, on := range f.On {
+			row = append(row, optionalNumber(on, "✓"))
+		}
+		row = append(row, strings.Join(f.SeenBy, ", "))
+		if runtimes {
+			row = append(row, optionalNumber(f.Runtime > 0, fmt.Sprintf("%d min", f.Runtime)))
+		}
+		if len(m.filter.streamable) > 0 {
+			row = append(row, strings.Join(f.Streaming, ", "))
+		}
+		rows[i] = row
+	}
+	m.table.SetRows(nil)
+	m.table.SetColumns(columns)
+	m.table.SetRows(rows)
+	if m.table.Cursor() >= len(rows) {
+		m.table.SetCursor(0)
+	}
+}
+
+func watchlistFilms(films []groupFilm) []WatchlistFilm {
+	out := make([]WatchlistFilm, len(films))
+	for i, f := range films {
+		out[i] = f.WatchlistFilm
+	}
+	return out
+}
+
+// applySummaries copies fetched details onto the films and collects the
+// streaming services the streaming filter cycles through.
+func (m *GroupWatchlistModel) applySummaries() {
+	services := map[string]bool{}
+	for i := range m.films {
+		s, ok := m.summaries[m.films[i].Slug]
+		if !ok {
+			continue
+		}
+		m.films[i].Director = s.Director
+		m.films[i].Runtime = s.Runtime
+		m.films[i].Rating = s.Rating
+		m.films[i].Genres = s.Genres
+		m.films[i].Streaming = s.Streaming
+		for _, name := range s.Streaming {
+			services[name] = true
+		}
+	}
+	m.filter.streamable = m.filter.streamable[:0:0]
+	for name := range services {
+		m.filter.streamable = append(m.filter.streamable, name)
+	}
+	sort.Strings(m.filter.streamable)
+}
+
+func (m *GroupWatchlistModel) startEnrichment() tea.Cmd {
+	m.enrich.stop()
+	var slugs []string
+	for _, f := range m.films {
+		if _, ok := m.summaries[f.Slug]; !ok && f.Slug != "" {
+			slugs = append(slugs, f.Slug)
+		}
+	}
+	if len(slugs) == 0 {
+		return nil
+	}
+	m.enrichRuns++
+	var cmd tea.Cmd
+	m.enrich, cmd = startEnrichment(m.enrichRuns, slugs, true)
+	return cmd
+}
+
+func (m GroupWatchlistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+		switch key {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			if m.err != nil || m.showTable {
+				m.enrich.stop()
+				m.enrich = enrichment{}
+				m.err = nil
+				m.showTable = false
+				m.exportStatus = exportStatus{}
+				m.input.Focus()
+				return m, textinput.Blink
+			}
+			return NewMenuModel(), nil
+
+		case "enter":
+			if m.showTable {
+				if i := m.table.Cursor(); i < len(m.visible) {
+					m.enrich.stop()
+					return newFilmDetailsModel(m.visible[i].Movie, m)
+				}
+				return m, nil
+			}
+			if m.loading {
+				return m, nil
+			}
+			if links.IsLink(m.input.Value()) {
+				return openLink(m.input.Value(), m)
+			}
+			users := parseUsernames(m.input.Value())
+			if len(users) < 2 {
+				m.err = fmt.Errorf("enter at least two usernames")
+				return m, nil
+			}
+			m.users = users
+			m.loading = true
+			m.input.Blur()
+			return m, tea.Batch(m.spinner.Tick, fetchGroupWatchlist(users))
+		}
+
+		if m.showTable {
+			switch key {
+			case "q":
+				m.quitting = true
+				return m, tea.Quit
+			case "m":
+				m.filter.MinCount = m.filter.MinCount%len(m.users) + 1
+				m.applyFilter()
+				return m, nil
+			case "h":
+				m.filter.HideSeen = !m.filter.HideSeen
+				m.applyFilter()
+				return m, nil
+			case "t":
+				m.filter.Runtime = nextOption(watchlistRuntimes, m.filter.Runtime)
+				m.applyFilter()
+				return m, nil
+			case "w":
+				m.filter.Streaming = nextOption(append([]string{""}, m.filter.streamable...), m.filter.Streaming)
+				m.applyFilter()
+				return m, nil
+			case "v":
+				if len(m.visible) > 0 {
+					m.enrich.stop()
+					movies := make([]Movie, len(m.visible))
+					for i, f := range m.visible {
+						movies[i] = f.Movie
+					}
+					return startPoll("Movie night: "+strings.Join(m.users, ", "), movies, m)
+				}
+				return m, nil
+			case "i":
+				if !m.enrich.running {
+					return m, m.startEnrichment()
+				}
+				return m, nil
+			case "e":
+				if len(m.visible) > 0 {
+					return m, m.exporter.Open(groupWatchlistTable(m.visible, m.users), "exports/group_watchlist")
+				}
+				return m, nil
+			}
+		}
+
+	case groupWatchlistMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.films = msg.films
+		m.filter = groupFilter{MinCount: 1, groupSize: len(msg.users)}
+		m.showTable = true
+		m.applySummaries()
+		m.applyFilter()
+		return m, nil
+
+	case filmSummaryMsg:
+		if msg.run != m.enrich.run || !m.enrich.running {
+			return m, nil
+		}
+		m.enrich.done++
+		if msg.err != nil {
+			m.enrich.failed++
+		} else {
+			m.summaries[msg.slug] = msg.summary
+			m.applySummaries()
+			m.applyFilter()
+		}
+		return m, m.enrich.wait()
+
+	case enrichDoneMsg:
+		if msg.run == m.enrich.run {
+			m.enrich.running = false
+		}
+		return m, nil
+
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.table.SetWidth(msg.Width - 4)
+		m.exporter.input.Width = msg.Width - 20
+	}
+
+	switch {
+	case m.loading:
+		m.spinner, cmd = m.spinner.Update(msg)
+	case m.showTable:
+		m.table, cmd = m.table.Update(msg)
+	default:
+		m.input, cmd = m.input.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m GroupWatchlistModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+	if m.exporter.active {
+		return m.exporter.View("Exporting group watchlist for: " + strings.Join(m.users, ", "))
+	}
+	if m.loading {
+		return fmt.Sprintf("\n\n   %s Fetching watchlists and diaries for %s...\n\n", m.spinner.View(), strings.Join(m.users, ", "))
+	}
+
+	if m.showTable {
+		header := lipgloss.JoinVertical(lipgloss.Left,
+			filmsTitleStyle.Render("Group watchlist: "+strings.Join(m.users, ", ")),
+			filmsFilterStyle.Render(m.filter.String()),
+		)
+		help := "(Enter to view film, 'm' how many watchlists, 'h' hide seen, 't' runtime, 'w' streaming, 'i' fetch details, 'v' start a poll, 'e' to export, Esc to go back)"
+
+		status := fmt.Sprintf("%d of %d films", len(m.visible), len(m.films))
+		if m.enrich.running {
+			status += "  " + m.enrich.View(20)
+		} else if (m.filter.Runtime != "" || m.filter.Streaming != "") && len(m.summaries) == 0 {
+			status += "  (press 'i' to fetch runtimes and streaming services first)"
+		} else if m.enrich.failed > 0 {
+			status += fmt.Sprintf("  (details unavailable for %d films)", m.enrich.failed)
+		}
+
+		blocks := []string{header}
+		if len(m.visible) == 0 {
+			blocks = append(blocks, "No films match these filters.")
+		} else {
+			blocks = append(blocks, m.baseStyle.Render(m.table.View()))
+		}
+		blocks = append(blocks, status, help)
+		view := lipgloss.JoinVertical(lipgloss.Left, blocks...)
+		if exportMsg := m.exportStatus.View("Group watchlist"); exportMsg != "" {
+			view += "\n" + exportMsg
+		}
+		return view
+	}
+
+	title := watchlistPageTitleStyle.Render("Group Watchlist")
+	inputBlock := lipgloss.JoinVertical(lipgloss.Left,
+		m.input.View(),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(strings.Repeat("─", m.input.Width+len(m.input.Prompt))),
+	)
+	help := watchHelpStyle.Render("type two or more usernames, separated by commas or spaces, and press enter")
+
+	final := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		inputBlock,
+		"\n\n\n",
+		help,
+	)
+	return lipgloss.NewStyle().Margin(1, 2).Render(final)
+}
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	listDescriptionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("252"))
+
+	listTagStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#4FC3F7"))
+
+	listNotesStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(0, 1)
+)
+
+// listDescriptionLines is how much of a long description the header shows.
+const listDescriptionLines = 4
+
+// ListFilm is one entry on a list. Rank is zero on unranked lists.
+type ListFilm struct {
+	Movie
+	Rank  int    `json:"rank,omitempty"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// ListDetails is a list's header and its entries.
+type ListDetails struct {
+	Name        string     `json:"name"`
+	Owner       string     `json:"owner"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Tags        []string   `json:"tags"`
+	Count       int        `json:"films_count"`
+	Likes       int        `json:"likes"`
+	Comments    int        `json:"comments"`
+	Ranked      bool       `json:"ranked"`
+	Films       []ListFilm `json:"films"`
+}
+
+// Movies returns the list's films without their ranks and notes.
+func (d ListDetails) Movies() []Movie {
+	movies := make([]Movie, len(d.Films))
+	for i, f := range d.Films {
+		movies[i] = f.Movie
+	}
+	return movies
+}
+
+func (d ListDetails) hasDirectors() bool {
+	for _, f := range d.Films {
+		if f.Director != "" && f.Director != "N/A" {
+			return true
+		}
+	}
+	return false
+}
+
+func (d ListDetails) hasNotes() bool {
+	for _, f := range d.Films {
+		if f.Notes != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func listTable(d ListDetails) export.Table {
+	t := export.Table{
+		Title: fmt.Sprintf("%s by %s", d.Name, d.Owner),
+	}
+	if d.Ranked {
+		t.Columns = append(t.Columns, export.Column{Name: "Rank", Numeric: true})
+	}
+	t.Columns = append(t.Columns,
+		export.Column{Name: "Title"},
+		export.Column{Name: "Year", Numeric: true},
+	)
+	directors, notes := d.hasDirectors(), d.hasNotes()
+	if directors {
+		t.Columns = append(t.Columns, export.Column{Name: "Director"})
+	}
+	if notes {
+		t.Columns = append(t.Columns, export.Column{Name: "Notes"})
+	}
+
+	for _, film := range d.Films {
+		var row []string
+		if d.Ranked {
+			row = append(row, fmt.Sprintf("%d", film.Rank))
+		}
+		row = append(row, film.Title, fmt.Sprintf("%d", film.Year))
+		if directors {
+			row = append(row, film.Director)
+		}
+		if notes {
+			row = append(row, film.Notes)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// firstLine returns the first line of s, marked with an ellipsis if there
+// is more.
+func firstLine(s string) string {
+	line, rest, found := strings.Cut(strings.TrimSpace(s), "\n")
+	if found && strings.TrimSpace(rest) != "" {
+		return strings.TrimSpace(line) + " …"
+	}
+	return line
+}
+
+func (m *ListsModel) updateDetailsRows() {
+	d := m.listDetails
+	directors, notes := d.hasDirectors(), d.hasNotes()
+
+	var columns []table.Column
+	if d.Ranked {
+		columns = append(columns, table.Column{Title: "#", Width: 5})
+	}
+	columns = append(columns,
+		table.Column{Title: "Title", Width: 40},
+		table.Column{Title: "Year", Width: 6},
+	)
+	if directors {
+		columns = append(columns, table.Column{Title: "Director", Width: 20})
+	}
+	if notes {
+		columns = append(columns, table.Column{Title: "Notes", Width: 30})
+	}
+	if m.changes != nil {
+		columns = append(columns, table.Column{Title: "", Width: 5})
+	}
+
+	rows := []table.Row{}
+	for _, film := range d.Films {
+		var row table.Row
+		if d.Ranked {
+			rank := ""
+			if film.Rank > 0 {
+				rank = fmt.Sprintf("%d", film.Rank)
+			}
+			row = append(row, rank)
+		}
+		row = append(row, film.Title, fmt.Sprintf("%d", film.Year))
+		if directors {
+			row = append(row, film.Director)
+		}
+		if notes {
+			row = append(row, firstLine(film.Notes))
+		}
+		if m.changes != nil {
+			marker := ""
+			if m.changes.isAdded(film.Movie) {
+				marker = "new"
+			}
+			row = append(row, marker)
+		}
+		rows = append(rows, row)
+	}
+
+	m.detailsTable = newListsTable(columns, rows, min(len(rows)+1, 15))
+	if m.width > 0 {
+		m.detailsTable.SetWidth(m.width - 4)
+	}
+}
+
+func (m ListsModel) textWidth() int {
+	if m.width > 0 && m.width < 100 {
+		return m.width - 6
+	}
+	return 94
+}
+
+// renderListHeader shows the list's description, tags and counts.
+func (m ListsModel) renderListHeader() string {
+	d := m.listDetails
+	var blocks []string
+
+	if d.Description != "" {
+		lines := strings.Split(lipgloss.NewStyle().Width(m.textWidth()).Render(d.Description), "\n")
+		if len(lines) > listDescriptionLines {
+			lines = append(lines[:listDescriptionLines], "…")
+		}
+		blocks = append(blocks, listDescriptionStyle.Render(strings.Join(lines, "\n")))
+	}
+	if len(d.Tags) > 0 {
+		tags := make([]string, len(d.Tags))
+		for i, t := range d.Tags {
+			tags[i] = listTagStyle.Render("#" + t)
+		}
+		blocks = append(blocks, strings.Join(tags, " "))
+	}
+
+	count := d.Count
+	if count < len(d.Films) {
+		count = len(d.Films)
+	}
+	stats := []string{fmt.Sprintf("%s films", formatLargeNumber(count))}
+	if d.Ranked {
+		stats = append(stats, "ranked")
+	}
+	stats = append(stats,
+		fmt.Sprintf("♥ %s", formatLargeNumber(d.Likes)),
+		fmt.Sprintf("%s comments", formatLargeNumber(d.Comments)),
+		"by "+m.selectedList.Owner,
+	)
+	blocks = append(blocks, listHelpStyle.Render(strings.Join(stats, " · ")))
+	return lipgloss.NewStyle().MarginBottom(1).Render(lipgloss.JoinVertical(lipgloss.Left, blocks...))
+}
+
+// renderSelectedNotes shows the full notes of the selected entry.
+func (m ListsModel) renderSelectedNotes() string {
+	cursor := m.detailsTable.Cursor()
+	if cursor < 0 || cursor >= len(m.listDetails.Films) {
+		return ""
+	}
+	film := m.listDetails.Films[cursor]
+	if film.Notes == "" {
+		return ""
+	}
+	return listNotesStyle.Width(m.textWidth()).Render(film.Notes)
+}
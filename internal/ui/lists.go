@@ -1,16 +1,14 @@
 package ui
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -35,6 +33,8 @@ var (
 
 	listHelpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("242"))
+	listFollowStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00A86B"))
 	exportInputStyle = lipgloss.NewStyle().MarginTop(1)
 )
 
@@ -42,6 +42,23 @@ type ListSearchResult struct {
 	Name  string `json:"name"`
 	Owner string `json:"owner"`
 	Slug  string `json:"slug"`
+	Films int    `json:"films,omitempty"`
+}
+
+// listsSource is where the lists shown in the lists table come from.
+type listsSource int
+
+const (
+	listsFromSearch listsSource = iota
+	listsByUser
+	listsLikedByUser
+	listsFollowed
+)
+
+type userListsPage struct {
+	Lists   []ListSearchResult `json:"lists"`
+	Page    int                `json:"page"`
+	HasMore bool               `json:"has_more"`
 }
 
 type searchListsResultMsg struct {
@@ -49,44 +66,69 @@ type searchListsResultMsg struct {
 	err   error
 }
 
-type listDetailsResultMsg struct {
-	movies []Movie
+type followToggledMsg struct {
+	following bool
+	err       error
+}
+
+type userListsResultMsg struct {
+	source listsSource
+	page   int
+	result userListsPage
 	err    error
 }
 
-type exportListResultMsg struct {
-	filePath string
-	err      error
+// listDetailsResultMsg carries a list's details. changes is nil unless the
+// list is followed.
+type listDetailsResultMsg struct {
+	list      ListSearchResult
+	details   ListDetails
+	changes   *listChanges
+	followErr error
+	err       error
 }
 
 type ListsModel struct {
-	input               textinput.Model
-	spinner             spinner.Model
-	table               table.Model
-	showSpinner         bool
-	showTable           bool
-	submitted           bool
-	quitting            bool
-	viewingDetails      bool
-	loadingDetails      bool
-	promptingExportPath bool
-	exportInput         textinput.Model
-	exportPath          string
-	exportErr           error
-	lastExportMsg       time.Time
-	selectedList        ListSearchResult
-	listDetails         []Movie
-	detailsTable        table.Model
-	lists               []ListSearchResult
-	err                 error
-	baseStyle           lipgloss.Style
+	input          textinput.Model
+	spinner        spinner.Model
+	table          table.Model
+	showSpinner    bool
+	showTable      bool
+	submitted      bool
+	quitting       bool
+	viewingDetails bool
+	loadingDetails bool
+	exporter       exportPrompt
+	exportStatus   exportStatus
+	selectedList   ListSearchResult
+	listDetails    ListDetails
+	detailsTable   table.Model
+	lists          []ListSearchResult
+	err            error
+	baseStyle      lipgloss.Style
+
+	// source, username, page and hasMore describe the lists in the table
+	// when they are a user's own, liked or followed lists rather than
+	// search results. back is the screen Esc returns to from them.
+	source      listsSource
+	username    string
+	page        int
+	hasMore     bool
+	loadingMore bool
+	checkedAt   map[string]time.Time
+	back        tea.Model
+	width       int
+
+	following    bool
+	changes      *listChanges
+	followStatus string
 }
 
 func NewListsModel() ListsModel {
 	ti := textinput.New()
-	ti.Placeholder = "Enter list search query..."
+	ti.Placeholder = "Enter a search query, list URL or owner/slug..."
 	ti.Focus()
-	ti.CharLimit = 64
+	ti.CharLimit = 128
 	ti.Width = 40
 
 	ti.Prompt = "Query: "
@@ -98,72 +140,59 @@ func NewListsModel() ListsModel {
 	sp.Spinner = spinner.Dot
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
 
-	exportTi := textinput.New()
-	exportTi.Placeholder = "e.g., my_list_export.csv or exports/my_list.csv"
-	exportTi.CharLimit = 256
-	exportTi.Width = 60
-	exportTi.Prompt = "Export Path (relative): "
-	exportTi.PromptStyle = listInputPromptStyle.Copy()
-	exportTi.Cursor.Style = listInputCursorStyle.Copy()
-	exportTi.TextStyle = listInputTextStyle.Copy()
+	exporter := newExportPrompt("e.g., my_list_export.csv or exports/my_list.csv",
+		listInputPromptStyle, listInputCursorStyle, listInputTextStyle)
 
 	return ListsModel{
-		input:       ti,
-		spinner:     sp,
-		exportInput: exportTi,
-		baseStyle:   lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+		input:     ti,
+		spinner:   sp,
+		exporter:  exporter,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
 	}
 }
 
-func callPythonSearchLists(query string) tea.Cmd {
-	return func() tea.Msg {
-		pyExecName := "search_lists"
-		if runtime.GOOS == "windows" {
-			pyExecName += ".exe"
-		}
-
-		baseDir := ""
-		snapDir := os.Getenv("SNAP")
-		if snapDir != "" {
-			baseDir = snapDir
-		} else {
-			goExecPath, err := os.Executable()
-			if err != nil {
-				return searchListsResultMsg{err: fmt.Errorf("fatal: could not get executable path: %w", err)}
-			}
-			baseDir = filepath.Dir(goExecPath)
-		}
-
-		pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
-
-		if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
-			wd, _ := os.Getwd()
-			arch := runtime.GOARCH
-			osDir := runtime.GOOS + "_" + arch
-			altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
+// NewUserListsModel shows the lists a user made, or the lists they liked.
+// Esc returns to back.
+func NewUserListsModel(username string, liked bool, back tea.Model) ListsModel {
+	m := NewListsModel()
+	m.input.Blur()
+	m.source = listsByUser
+	if liked {
+		m.source = listsLikedByUser
+	}
+	m.username = username
+	m.back = back
+	m.submitted = true
+	m.showSpinner = true
+	m.page = 1
+	return m
+}
 
-			if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
-				pyExecPath = altPyExecPath
-			} else {
-				return searchListsResultMsg{err: fmt.Errorf("python executable not found at %s or %s",
-					filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
-					filepath.Join("project_root", "dist_py", osDir, pyExecName))}
-			}
-		}
+// newListModel opens the list ref straight away. Esc from it returns to
+// the lists search.
+func newListModel(ref links.ListRef) (ListsModel, tea.Cmd) {
+	m := NewListsModel()
+	m.input.SetValue(ref.String())
+	m.input.Blur()
+	m.submitted = true
+	return m.openList(ListSearchResult{Name: ref.String(), Owner: ref.Owner, Slug: ref.Slug})
+}
 
-		cmd := exec.Command(pyExecPath, query)
-		out, err := cmd.Output()
+// NewFollowedListsModel shows the lists followed on this computer.
+func NewFollowedListsModel() ListsModel {
+	m := NewListsModel()
+	m.input.Blur()
+	m.source = listsFollowed
+	m.submitted = true
+	m.showSpinner = true
+	return m
+}
 
+func callPythonSearchLists(query string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := runPyExec("search_lists", query)
 		if err != nil {
-			var errData map[string]string
-			if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
-				return searchListsResultMsg{err: fmt.Errorf(errData["error"])}
-			}
-			return searchListsResultMsg{err: fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))}
-		}
-		var maybeErr map[string]string
-		if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
-			return searchListsResultMsg{err: fmt.Errorf(maybeErr["error"])}
+			return searchListsResultMsg{err: err}
 		}
 		var lists []ListSearchResult
 		if err := json.Unmarshal(out, &lists); err != nil {
@@ -173,143 +202,185 @@ func callPythonSearchLists(query string) tea.Cmd {
 	}
 }
 
-func callPythonGetListDetails(owner, slug string) tea.Cmd {
+func callPythonGetListDetails(owner, slug string) (ListDetails, error) {
+	out, err := runPyExec("get_list_details", owner, slug)
+	if err != nil {
+		return ListDetails{}, err
+	}
+	var details ListDetails
+	if err := json.Unmarshal(out, &details); err != nil {
+		return ListDetails{}, fmt.Errorf("failed to parse list details JSON: %w", err)
+	}
+	return details, nil
+}
+
+// fetchListDetails loads a list's films and, if the list is followed,
+// compares them with the films it had when it was last opened.
+func fetchListDetails(l ListSearchResult) tea.Cmd {
 	return func() tea.Msg {
-		pyExecName := "get_list_details"
-		if runtime.GOOS == "windows" {
-			pyExecName += ".exe"
+		details, err := callPythonGetListDetails(l.Owner, l.Slug)
+		if err != nil {
+			return listDetailsResultMsg{err: err}
 		}
-
-		baseDir := ""
-		snapDir := os.Getenv("SNAP")
-		if snapDir != "" {
-			baseDir = snapDir
-		} else {
-			goExecPath, err := os.Executable()
-			if err != nil {
-				return listDetailsResultMsg{err: fmt.Errorf("fatal: could not get executable path: %w", err)}
-			}
-			baseDir = filepath.Dir(goExecPath)
+		if details.Name != "" {
+			l.Name = details.Name
 		}
+		l, changes, followErr := checkFollowed(l, details.Movies())
+		return listDetailsResultMsg{list: l, details: details, changes: changes, followErr: followErr}
+	}
+}
+
+func callPythonGetUserLists(username string, source listsSource, page int) (userListsPage, error) {
+	kind := "lists"
+	if source == listsLikedByUser {
+		kind = "liked"
+	}
+	out, err := runPyExec("get_user_lists", username, kind, strconv.Itoa(page))
+	if err != nil {
+		return userListsPage{}, err
+	}
+	var result userListsPage
+	if err := json.Unmarshal(out, &result); err != nil {
+		return userListsPage{}, fmt.Errorf("failed to parse user lists JSON: %w", err)
+	}
+	return result, nil
+}
 
-		pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
+func fetchUserLists(username string, source listsSource, page int) tea.Cmd {
+	return func() tea.Msg {
+		result, err := callPythonGetUserLists(username, source, page)
+		return userListsResultMsg{source: source, page: page, result: result, err: err}
+	}
+}
 
-		if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
-			wd, _ := os.Getwd()
-			arch := runtime.GOARCH
-			osDir := runtime.GOOS + "_" + arch
-			altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
+func newListsTable(columns []table.Column, rows []table.Row, height int) table.Model {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(height),
+	)
 
-			if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
-				pyExecPath = altPyExecPath
-			} else {
-				return listDetailsResultMsg{err: fmt.Errorf("python executable not found at %s or %s",
-					filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
-					filepath.Join("project_root", "dist_py", osDir, pyExecName))}
-			}
-		}
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+	return t
+}
 
-		cmd := exec.Command(pyExecPath, owner, slug)
-		out, err := cmd.Output()
+// ListTable fetches the films on the list owner/slug as an export table,
+// for use outside the interactive interface.
+func ListTable(owner, slug string) (export.Table, error) {
+	details, err := callPythonGetListDetails(owner, slug)
+	if err != nil {
+		return export.Table{}, err
+	}
+	return listTable(details), nil
+}
 
-		if err != nil {
-			var errData map[string]string
-			if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
-				return listDetailsResultMsg{err: fmt.Errorf(errData["error"])}
-			}
-			return listDetailsResultMsg{err: fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))}
-		}
-		var maybeErr map[string]string
-		if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
-			return listDetailsResultMsg{err: fmt.Errorf(maybeErr["error"])}
-		}
-		var movies []Movie
-		if err := json.Unmarshal(out, &movies); err != nil {
-			return listDetailsResultMsg{err: fmt.Errorf("failed to parse list details JSON: %w", err)}
-		}
-		return listDetailsResultMsg{movies: movies}
+func (m ListsModel) Init() tea.Cmd {
+	switch m.source {
+	case listsByUser, listsLikedByUser:
+		return tea.Batch(m.spinner.Tick, fetchUserLists(m.username, m.source, 1))
+	case listsFollowed:
+		return tea.Batch(m.spinner.Tick, loadFollowedLists)
 	}
+	return textinput.Blink
 }
-func exportListToCSV(movies []Movie, listName, owner, relativeFilePath string) tea.Cmd {
-	return func() tea.Msg {
-		filePath, err := filepath.Abs(relativeFilePath)
-		if err != nil {
-			return exportListResultMsg{err: fmt.Errorf("invalid path format: %w", err)}
-		}
 
-		dir := filepath.Dir(filePath)
-		if dir != "." && dir != "/" {
-			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-				if os.IsPermission(err) {
-					return exportListResultMsg{err: fmt.Errorf("permission denied creating directory %s", dir)}
-				}
-				return exportListResultMsg{err: fmt.Errorf("failed to create directory %s: %w", dir, err)}
-			}
+func (m ListsModel) goBack() (tea.Model, tea.Cmd) {
+	if m.back != nil {
+		return m.back, nil
+	}
+	return NewMenuModel(), nil
+}
+
+// updateListRows rebuilds the lists table from m.lists, keeping the
+// cursor where it was.
+func (m *ListsModel) updateListRows() {
+	columns := []table.Column{
+		{Title: "List Name", Width: 40},
+		{Title: "Owner", Width: 25},
+	}
+	if m.source != listsFromSearch {
+		columns = []table.Column{
+			{Title: "List Name", Width: 40},
+			{Title: "Owner", Width: 20},
+			{Title: "Films", Width: 6},
 		}
+	}
+	if m.source == listsFollowed {
+		columns = append(columns, table.Column{Title: "Last Checked", Width: 18})
+	}
 
-		file, err := os.Create(filePath)
-		if err != nil {
-			if os.IsPermission(err) {
-				return exportListResultMsg{err: fmt.Errorf("permission denied creating file %s", filePath)}
+	rows := []table.Row{}
+	for _, l := range m.lists {
+		row := table.Row{l.Name, l.Owner}
+		if m.source != listsFromSearch {
+			films := ""
+			if l.Films > 0 {
+				films = formatLargeNumber(l.Films)
 			}
-			return exportListResultMsg{err: fmt.Errorf("failed to create file %s: %w", filePath, err)}
+			row = append(row, films)
+		}
+		if m.source == listsFollowed {
+			row = append(row, m.checkedAt[followKey(l)].Format("2 Jan 2006 15:04"))
 		}
-		defer file.Close()
+		rows = append(rows, row)
+	}
 
-		writer := csv.NewWriter(file)
-		defer writer.Flush()
+	cursor := m.table.Cursor()
+	m.table = newListsTable(columns, rows, 10)
+	if m.width > 0 {
+		m.table.SetWidth(m.width - 4)
+	}
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	} else if len(rows) > 0 {
+		m.table.SetCursor(len(rows) - 1)
+	}
+}
 
-		header := []string{"Title", "Year"}
-		if err := writer.Write(header); err != nil {
-			return exportListResultMsg{err: fmt.Errorf("failed to write CSV header: %w", err)}
-		}
+// reloadUserLists starts over from the first page after switching between
+// a user's own lists and the lists they liked.
+func (m *ListsModel) reloadUserLists() tea.Cmd {
+	m.lists = nil
+	m.page = 1
+	m.hasMore = false
+	m.loadingMore = false
+	m.showSpinner = true
+	m.table.SetCursor(0)
+	return tea.Batch(m.spinner.Tick, fetchUserLists(m.username, m.source, 1))
+}
 
-		for _, movie := range movies {
-			row := []string{movie.Title, fmt.Sprintf("%d", movie.Year)}
-			if err := writer.Write(row); err != nil {
-				fmt.Printf("Error writing row for %s: %v\n", movie.Title, err)
-			}
+func (m ListsModel) toggleFollow() tea.Cmd {
+	l, movies, following := m.selectedList, m.listDetails.Movies(), m.following
+	return func() tea.Msg {
+		if following {
+			return followToggledMsg{following: false, err: unfollowList(l)}
 		}
-
-		return exportListResultMsg{filePath: filePath}
+		return followToggledMsg{following: true, err: followList(l, movies)}
 	}
 }
 
-func (m ListsModel) Init() tea.Cmd {
-	return textinput.Blink
+func (m ListsModel) openList(l ListSearchResult) (ListsModel, tea.Cmd) {
+	m.selectedList = l
+	m.loadingDetails = true
+	m.showSpinner = true
+	return m, tea.Batch(m.spinner.Tick, fetchListDetails(l))
 }
 
 func (m ListsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
-	if m.promptingExportPath {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "ctrl+c", "q":
-				m.quitting = true
-				return m, tea.Quit
-			case "esc":
-				m.promptingExportPath = false
-				m.exportInput.Blur()
-				m.exportInput.Reset()
-				m.exportErr = nil
-				return m, nil
-			case "enter":
-				m.promptingExportPath = false
-				m.exportInput.Blur()
-				path := m.exportInput.Value()
-				m.exportInput.Reset()
-				m.exportPath = ""
-				m.exportErr = nil
-				cmds = append(cmds, exportListToCSV(m.listDetails, m.selectedList.Name, m.selectedList.Owner, path))
-				return m, tea.Batch(cmds...)
-			}
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
 		}
-		m.exportInput, cmd = m.exportInput.Update(msg)
-		cmds = append(cmds, cmd)
-		return m, tea.Batch(cmds...)
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
 	}
 
 	switch msg := msg.(type) {
@@ -320,55 +391,103 @@ func (m ListsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case "esc":
+			if m.loadingDetails {
+				return m, nil
+			}
 			if m.err != nil {
 				m.err = nil
+				if m.source != listsFromSearch {
+					if !m.showTable {
+						return m.goBack()
+					}
+					return m, nil
+				}
 				m.showTable = false
 				m.submitted = false
 				m.input.Focus()
 				return m, nil
 			}
-			if m.loadingDetails {
-				return m, nil
-			}
 			if m.viewingDetails {
 				m.viewingDetails = false
-				m.listDetails = nil
-				m.exportPath = ""
-				m.exportErr = nil
+				m.listDetails = ListDetails{}
+				m.changes = nil
+				m.followStatus = ""
+				m.exportStatus = exportStatus{}
+				if !m.showTable {
+					// The list was opened directly from the input.
+					m.submitted = false
+					m.input.Focus()
+					return m, textinput.Blink
+				}
+				if m.source == listsFollowed {
+					// Pick up the new check times and film counts.
+					return m, loadFollowedLists
+				}
 				return m, nil
-			} else if m.showTable {
+			} else if m.showTable && m.source == listsFromSearch {
 				m.showTable = false
 				m.submitted = false
 				m.input.Focus()
 				return m, nil
 			} else {
-				return NewMenuModel(), nil
+				return m.goBack()
 			}
 
 		case "enter":
 			if !m.submitted {
 				m.submitted = true
+				if ref, ok := links.ParseList(m.input.Value()); ok {
+					m, cmd = m.openList(ListSearchResult{Name: ref.String(), Owner: ref.Owner, Slug: ref.Slug})
+					return m, cmd
+				}
+				if links.IsLink(m.input.Value()) {
+					m.submitted = false
+					return openLink(m.input.Value(), m)
+				}
 				m.showSpinner = true
 				cmds = append(cmds, m.spinner.Tick, callPythonSearchLists(m.input.Value()))
-			} else if m.showTable && !m.viewingDetails {
+			} else if m.showTable && !m.viewingDetails && !m.showSpinner {
 				cursor := m.table.Cursor()
 				if len(m.lists) > cursor {
-					m.selectedList = m.lists[cursor]
-					m.loadingDetails = true
-					m.showSpinner = true
-					cmds = append(cmds, m.spinner.Tick, callPythonGetListDetails(m.selectedList.Owner, m.selectedList.Slug))
+					m, cmd = m.openList(m.lists[cursor])
+					return m, cmd
+				}
+			} else if m.viewingDetails {
+				cursor := m.detailsTable.Cursor()
+				if cursor < len(m.listDetails.Films) {
+					return newFilmDetailsModel(m.listDetails.Films[cursor].Movie, m)
+				}
+			}
+
+		case "l":
+			if m.showTable && !m.viewingDetails && !m.showSpinner &&
+				(m.source == listsByUser || m.source == listsLikedByUser) {
+				if m.source == listsByUser {
+					m.source = listsLikedByUser
+				} else {
+					m.source = listsByUser
 				}
+				return m, m.reloadUserLists()
+			}
+
+		case "f":
+			if m.viewingDetails {
+				return m, m.toggleFollow()
+			}
+
+		case "v":
+			if m.viewingDetails && len(m.listDetails.Films) > 0 {
+				movies := make([]Movie, len(m.listDetails.Films))
+				for i, f := range m.listDetails.Films {
+					movies[i] = f.Movie
+				}
+				return startPoll(fmt.Sprintf("What should we watch from %s?", m.selectedList.Name), movies, m)
 			}
 
 		case "e":
-			if m.viewingDetails && len(m.listDetails) > 0 {
-				m.promptingExportPath = true
-				m.exportInput.Focus()
-				safeListName := strings.ReplaceAll(strings.ReplaceAll(m.selectedList.Name, "/", "_"), " ", "_")
-				safeOwner := strings.ReplaceAll(strings.ReplaceAll(m.selectedList.Owner, "/", "_"), " ", "_")
-				defaultFileName := fmt.Sprintf("exports/list_%s_%s.csv", safeOwner, safeListName)
-				m.exportInput.SetValue(defaultFileName)
-				return m, textinput.Blink
+			if m.viewingDetails && len(m.listDetails.Films) > 0 {
+				baseName := fmt.Sprintf("exports/list_%s_%s", safeFileName(m.selectedList.Owner), safeFileName(m.selectedList.Name))
+				return m, m.exporter.Open(listTable(m.listDetails), baseName)
 			}
 
 		}
@@ -380,31 +499,46 @@ func (m ListsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.showTable = true
 			m.lists = msg.lists
+			m.table.SetCursor(0)
+			m.updateListRows()
+		}
+		return m, nil
 
-			rows := []table.Row{}
-			for _, l := range m.lists {
-				rows = append(rows, table.Row{l.Name, l.Owner})
-			}
-
-			columns := []table.Column{
-				{Title: "List Name", Width: 40},
-				{Title: "Owner", Width: 25},
+	case userListsResultMsg:
+		// Drop pages for the other kind of list after switching with 'l'.
+		if msg.source != m.source {
+			return m, nil
+		}
+		m.showSpinner = false
+		m.loadingMore = false
+		if msg.err != nil {
+			if len(m.lists) == 0 {
+				m.err = msg.err
 			}
+			m.hasMore = false
+			return m, nil
+		}
+		m.showTable = true
+		m.page = msg.page
+		m.hasMore = msg.result.HasMore
+		m.lists = append(m.lists, msg.result.Lists...)
+		m.updateListRows()
+		return m, nil
 
-			t := table.New(
-				table.WithColumns(columns),
-				table.WithRows(rows),
-				table.WithFocused(true),
-				table.WithHeight(10),
-			)
-
-			s := table.DefaultStyles()
-			s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
-			s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
-			t.SetStyles(s)
-
-			m.table = t
+	case followedListsMsg:
+		m.showSpinner = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.showTable = true
+		m.lists = nil
+		m.checkedAt = map[string]time.Time{}
+		for _, f := range msg.lists {
+			m.lists = append(m.lists, f.List)
+			m.checkedAt[followKey(f.List)] = f.CheckedAt
 		}
+		m.updateListRows()
 		return m, nil
 
 	case listDetailsResultMsg:
@@ -414,62 +548,60 @@ func (m ListsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 		} else {
 			m.viewingDetails = true
-			m.listDetails = msg.movies
-
-			rows := []table.Row{}
-			for _, movie := range m.listDetails {
-				rows = append(rows, table.Row{
-					movie.Title,
-					fmt.Sprintf("%d", movie.Year),
-				})
+			m.selectedList = msg.list
+			m.listDetails = msg.details
+			m.changes = msg.changes
+			m.following = msg.changes != nil
+			m.followStatus = ""
+			if msg.followErr != nil {
+				m.followStatus = fmt.Sprintf("Could not check for changes: %v", msg.followErr)
 			}
+			m.updateDetailsRows()
+		}
+		return m, nil
 
-			columns := []table.Column{
-				{Title: "Title", Width: 40},
-				{Title: "Year", Width: 6},
-			}
-
-			t := table.New(
-				table.WithColumns(columns),
-				table.WithRows(rows),
-				table.WithFocused(true),
-				table.WithHeight(min(len(rows)+1, 20)),
-			)
-
-			s := table.DefaultStyles()
-			s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
-			s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
-			t.SetStyles(s)
-
-			m.detailsTable = t
+	case followToggledMsg:
+		switch {
+		case msg.err != nil:
+			m.followStatus = fmt.Sprintf("Could not update followed lists: %v", msg.err)
+		case msg.following:
+			m.following = true
+			m.followStatus = "Following. Films added or removed will show the next time you open this list."
+		default:
+			m.following = false
+			m.followStatus = "No longer following this list."
 		}
 		return m, nil
 
-	case exportListResultMsg:
-		m.exportErr = msg.err
-		m.exportPath = msg.filePath
-		m.lastExportMsg = time.Now()
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
 		return m, nil
 
 	case tea.WindowSizeMsg:
+		m.width = msg.Width
 		if m.showTable {
 			m.table.SetWidth(msg.Width - 4)
 		}
 		if m.viewingDetails {
 			m.detailsTable.SetWidth(msg.Width - 4)
 		}
-		m.exportInput.Width = msg.Width - 20
+		m.exporter.input.Width = msg.Width - 20
 	}
 
 	if m.showSpinner {
 		m.spinner, cmd = m.spinner.Update(msg)
 		cmds = append(cmds, cmd)
-	} else if !m.showTable {
+	} else if !m.showTable && !m.viewingDetails {
 		m.input, cmd = m.input.Update(msg)
 		cmds = append(cmds, cmd)
 	} else if !m.viewingDetails {
 		m.table, cmd = m.table.Update(msg)
 		cmds = append(cmds, cmd)
+
+		if m.hasMore && !m.loadingMore && m.table.Cursor() >= len(m.lists)-3 {
+			m.loadingMore = true
+			cmds = append(cmds, fetchUserLists(m.username, m.source, m.page+1))
+		}
 	} else {
 		m.detailsTable, cmd = m.detailsTable.Update(msg)
 		cmds = append(cmds, cmd)
@@ -478,6 +610,92 @@ func (m ListsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+func (m ListsModel) listsTitle() string {
+	switch m.source {
+	case listsByUser:
+		return fmt.Sprintf("@%s's lists", m.username)
+	case listsLikedByUser:
+		return fmt.Sprintf("Lists @%s liked", m.username)
+	case listsFollowed:
+		return "Followed lists"
+	}
+	return ""
+}
+
+func (m ListsModel) renderDetails() string {
+	title := listPageTitleStyle.Render(fmt.Sprintf("Movies in: %s", m.selectedList.Name))
+	exportMsg := m.exportStatus.View("List")
+
+	follow := "'f' to follow"
+	blocks := []string{lipgloss.NewStyle().Margin(1, 0, 0, 0).Render(title), m.renderListHeader()}
+	if m.following {
+		follow = "'f' to unfollow"
+		line := "Following"
+		if m.changes != nil {
+			line += " · " + m.changes.summary()
+		}
+		blocks = append(blocks, listFollowStyle.Render(line))
+	}
+	blocks = append(blocks, m.baseStyle.Render(m.detailsTable.View()))
+	if notes := m.renderSelectedNotes(); notes != "" {
+		blocks = append(blocks, notes)
+	}
+
+	if m.changes != nil && len(m.changes.Removed) > 0 {
+		removed := make([]string, len(m.changes.Removed))
+		for i, movie := range m.changes.Removed {
+			removed[i] = fmt.Sprintf("%s (%d)", movie.Title, movie.Year)
+		}
+		blocks = append(blocks, listHelpStyle.Render("Removed: "+strings.Join(removed, ", ")))
+	}
+	if m.followStatus != "" {
+		blocks = append(blocks, m.followStatus)
+	}
+	blocks = append(blocks, fmt.Sprintf("\n(Use ↑/↓ to navigate, Enter to view film, %s, 'v' start a poll, 'e' to export, Esc to go back)", follow))
+
+	viewContent := lipgloss.JoinVertical(lipgloss.Left, blocks...)
+	if exportMsg != "" {
+		viewContent += "\n" + exportMsg
+	}
+	return viewContent
+}
+
+func (m ListsModel) renderListsTable() string {
+	if m.source == listsFromSearch {
+		return m.baseStyle.Render(m.table.View()) + "\n(Use ↑/↓ to scroll, Enter to select, Esc to go back)"
+	}
+
+	title := listPageTitleStyle.Render(m.listsTitle())
+	help := "(Use ↑/↓ to scroll, Enter to select, Esc to go back)"
+	switch m.source {
+	case listsByUser:
+		help = "(Use ↑/↓ to scroll, Enter to select, 'l' for liked lists, Esc to go back)"
+	case listsLikedByUser:
+		help = "(Use ↑/↓ to scroll, Enter to select, 'l' for their own lists, Esc to go back)"
+	case listsFollowed:
+		help = "(Use ↑/↓ to scroll, Enter to open and see what changed, Esc to go back)"
+	}
+
+	if len(m.lists) == 0 {
+		empty := "No lists here yet."
+		if m.source == listsFollowed {
+			empty = "You are not following any lists. Press 'f' while viewing a list to follow it."
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, title, empty, "", help)
+	}
+
+	status := fmt.Sprintf("%d lists", len(m.lists))
+	if m.loadingMore {
+		status += fmt.Sprintf(", loading page %d...", m.page+1)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		m.baseStyle.Render(m.table.View()),
+		status,
+		help,
+	)
+}
+
 func (m ListsModel) View() string {
 	if m.quitting {
 		return "Goodbye!"
@@ -486,45 +704,31 @@ func (m ListsModel) View() string {
 		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
 	}
 
-	if m.promptingExportPath {
-		return lipgloss.JoinVertical(lipgloss.Left,
-			fmt.Sprintf("Exporting list: %s by %s", m.selectedList.Name, m.selectedList.Owner),
-			exportInputStyle.Render(m.exportInput.View()),
-			"\n(Enter path and press Enter to confirm, Esc to cancel)",
-		)
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting list: %s by %s", m.selectedList.Name, m.selectedList.Owner))
 	}
 
 	if m.loadingDetails {
 		return fmt.Sprintf("\n\n   %s Fetching details for '%s'...\n\n", m.spinner.View(), m.selectedList.Name)
 	}
 	if m.showSpinner {
+		switch m.source {
+		case listsByUser:
+			return fmt.Sprintf("\n\n   %s Fetching @%s's lists...\n\n", m.spinner.View(), m.username)
+		case listsLikedByUser:
+			return fmt.Sprintf("\n\n   %s Fetching lists @%s liked...\n\n", m.spinner.View(), m.username)
+		case listsFollowed:
+			return fmt.Sprintf("\n\n   %s Loading followed lists...\n\n", m.spinner.View())
+		}
 		return fmt.Sprintf("\n\n   %s Searching for lists matching '%s'...\n\n", m.spinner.View(), m.input.Value())
 	}
 
 	if m.viewingDetails {
-		title := listPageTitleStyle.Render(fmt.Sprintf("Movies in: %s", m.selectedList.Name))
-		exportMsg := ""
-		if time.Since(m.lastExportMsg) < 5*time.Second {
-			if m.exportErr != nil {
-				exportMsg = exportStatusStyle.Render(fmt.Sprintf("Export failed: %v", m.exportErr))
-			} else if m.exportPath != "" {
-				exportMsg = exportStatusStyle.Render(fmt.Sprintf("List exported to %s", m.exportPath))
-			}
-		}
-
-		viewContent := lipgloss.JoinVertical(lipgloss.Left,
-			lipgloss.NewStyle().Margin(1, 0).Render(title),
-			m.baseStyle.Render(m.detailsTable.View()),
-			"\n(Use ↑/↓ to navigate, 'e' to export, Esc to go back)",
-		)
-		if exportMsg != "" {
-			viewContent += "\n" + exportMsg
-		}
-		return viewContent
+		return m.renderDetails()
 	}
 
 	if m.showTable {
-		return m.baseStyle.Render(m.table.View()) + "\n(Use ↑/↓ to scroll, Enter to select, Esc to go back)"
+		return m.renderListsTable()
 	}
 
 	title := listPageTitleStyle.Render("Search Letterboxd Lists")
@@ -532,7 +736,7 @@ func (m ListsModel) View() string {
 		m.input.View(),
 		lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(strings.Repeat("─", m.input.Width+len(m.input.Prompt))),
 	)
-	help := listHelpStyle.Render("type a query and press enter")
+	help := listHelpStyle.Render("type a query, or paste a list URL or owner/slug, and press enter")
 
 	final := lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/cache"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const memberFilmMaxAge = 24 * time.Hour
+
+// memberFilm is how one member rated and reviewed a film.
+type memberFilm struct {
+	Username string  `json:"username"`
+	Name     string  `json:"-"`
+	Watched  bool    `json:"watched"`
+	Rating   float64 `json:"rating"`
+	Review   string  `json:"review"`
+	Date     string  `json:"date"`
+	URL      string  `json:"url"`
+
+	// Error says why this member could not be looked up.
+	Error string `json:"error,omitempty"`
+}
+
+func (r memberFilm) displayName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Username
+}
+
+// loadMemberFilms looks up how each of usernames rated the film slug, in
+// the same order. Cached entries are used where they are recent enough
+// and the rest are fetched in one run of the script. Films members have
+// not seen are cached too; members who could not be looked up come back
+// with Error set.
+func loadMemberFilms(usernames []string, slug string) ([]memberFilm, error) {
+	films := make([]memberFilm, len(usernames))
+	key := func(username string) string { return strings.ToLower(username) + "/" + slug }
+
+	store, _ := cache.Open("member-films", memberFilmMaxAge)
+	var missing []int
+	for i, username := range usernames {
+		if store != nil {
+			if data, ok := store.Get(key(username)); ok && json.Unmarshal(data, &films[i]) == nil {
+				continue
+			}
+		}
+		missing = append(missing, i)
+	}
+	if len(missing) == 0 {
+		return films, nil
+	}
+
+	args := []string{slug}
+	for _, i := range missing {
+		args = append(args, usernames[i])
+	}
+	out, err := runPyExec("get_user_film", args...)
+	if err != nil {
+		return nil, err
+	}
+	var fetched []memberFilm
+	if err := json.Unmarshal(out, &fetched); err != nil {
+		return nil, fmt.Errorf("failed to parse rating JSON: %w", err)
+	}
+	if len(fetched) != len(missing) {
+		return nil, fmt.Errorf("asked for %d ratings, got %d", len(missing), len(fetched))
+	}
+	for j, i := range missing {
+		films[i] = fetched[j]
+		if fetched[j].Error != "" || store == nil {
+			continue
+		}
+		if data, err := json.Marshal(fetched[j]); err == nil {
+			_ = store.Put(key(usernames[i]), data)
+		}
+	}
+	return films, nil
+}
+
+// loadMemberFilm looks up how username rated the film slug.
+func loadMemberFilm(username, slug string) (memberFilm, error) {
+	films, err := loadMemberFilms([]string{username}, slug)
+	if err != nil {
+		return memberFilm{}, err
+	}
+	if films[0].Error != "" {
+		return memberFilm{}, errors.New(films[0].Error)
+	}
+	return films[0], nil
+}
+
+func memberHeader(r memberFilm) string {
+	parts := []string{movieAuthorStyle.Render(r.displayName())}
+	if r.Rating > 0 {
+		parts = append(parts, movieRatingStyle.Render(starLabel(r.Rating)))
+	}
+	var meta []string
+	if r.Date != "" {
+		meta = append(meta, r.Date)
+	}
+	if r.Review != "" {
+		meta = append(meta, "reviewed")
+	} else if r.Watched && r.Rating == 0 {
+		meta = append(meta, "watched")
+	}
+	if len(meta) > 0 {
+		parts = append(parts, movieSubtitleStyle.Render(strings.Join(meta, " · ")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// linkedReviewMsg is the review a review link pointed to.
+type linkedReviewMsg struct {
+	slug   string
+	review memberFilm
+	err    error
+}
+
+func fetchLinkedReview(username, slug string) tea.Cmd {
+	return func() tea.Msg {
+		r, err := loadMemberFilm(username, slug)
+		if r.Username == "" {
+			r.Username = username
+		}
+		if r.URL == "" {
+			r.URL = fmt.Sprintf("https://letterboxd.com/%s/film/%s/", username, slug)
+		}
+		return linkedReviewMsg{slug: slug, review: r, err: err}
+	}
+}
+
+// openLinkedReview opens the review a link pointed to. When it cannot be
+// read here the reader says why and keeps the link to the review page.
+func (m *SearchModel) openLinkedReview(msg linkedReviewMsg) {
+	r := msg.review
+	text := r.Review
+	switch {
+	case msg.err != nil:
+		text = fmt.Sprintf("Could not load @%s's review: %v", r.Username, msg.err)
+	case r.Review != "":
+	case r.Watched:
+		text = fmt.Sprintf("@%s logged this film without a review.", r.Username)
+	default:
+		text = fmt.Sprintf("@%s has no review of this film.", r.Username)
+	}
+	m.openReader(memberHeader(r), text, r.URL)
+}
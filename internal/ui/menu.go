@@ -136,13 +136,32 @@ func (d menuItemDelegate) Height() int                             { return 1 }
 func (d menuItemDelegate) Spacing() int                            { return 0 }
 func (d menuItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 
+// quickKeys select menu items directly, one key per item in order. The
+// letters carry on after 9 and skip the list's j/k and the q to quit.
+const quickKeys = "123456789abcdefghi"
+
+// quickKeyRange describes the quick-select keys for n items, e.g. "1-9, a-b".
+func quickKeyRange(n int) string {
+	n = min(n, len(quickKeys))
+	if n <= 9 {
+		return fmt.Sprintf("1-%d", n)
+	}
+	if n == 10 {
+		return "1-9, a"
+	}
+	return fmt.Sprintf("1-9, a-%c", quickKeys[n-1])
+}
+
 func (d menuItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	i, ok := listItem.(menuItem)
 	if !ok {
 		return
 	}
 	itemText := string(i)
-	numberStr := fmt.Sprintf("%d", index+1)
+	numberStr := " "
+	if index < len(quickKeys) {
+		numberStr = string(quickKeys[index])
+	}
 	var circleColor lipgloss.Color
 	colorIndex := index % 4
 	switch colorIndex {
@@ -161,7 +180,7 @@ func (d menuItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	numCircle := navCircleStyle(circleColor, numberStr).String()
 	numCircleStyled := lipgloss.NewStyle().Width(5).Render(numCircle)
 
-	shortcut := menuShortcutStyle.Render(fmt.Sprintf("[%d]", index+1))
+	shortcut := menuShortcutStyle.Render(fmt.Sprintf("[%s]", numberStr))
 	shortcutWidth := lipgloss.Width(shortcut)
 
 	if index == m.Index() {
@@ -213,6 +232,12 @@ func NewMenuModel() MenuModel {
 		menuItem(item("diary")),
 		menuItem(item("watchlist")),
 		menuItem(item("view lists")),
+		menuItem(item("search people")),
+		menuItem(item("followed lists")),
+		menuItem(item("open link")),
+		menuItem(item("group watchlist")),
+		menuItem(item("recommendations")),
+		menuItem(item("friends' activity")),
 	}
 	const defaultWidth = 35
 	listHeight := len(items)
@@ -234,6 +259,36 @@ func NewMenuModel() MenuModel {
 	}
 }
 
+// menuChoice maps a menu item to the Choice that RootModel switches on.
+func menuChoice(i menuItem) string {
+	switch string(i) {
+	case "search movie":
+		return "Search a movie"
+	case "user profile":
+		return "View a person's profile"
+	case "diary":
+		return "Get diary of a person"
+	case "watchlist":
+		return "Get Watchlist"
+	case "view lists":
+		return "View Lists of Letterboxd"
+	case "search people":
+		return "Search people"
+	case "followed lists":
+		return "View followed lists"
+	case "open link":
+		return "Open a Letterboxd link"
+	case "group watchlist":
+		return "Compare group watchlists"
+	case "recommendations":
+		return "Recommend films"
+	case "friends' activity":
+		return "View friends' activity"
+	default:
+		return ""
+	}
+}
+
 func loadRandomQuote() tea.Msg {
 	var csvPath string
 	snapDir := os.Getenv("SNAP")
@@ -356,26 +411,13 @@ func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "esc":
 			return m, nil
 
-		case "1", "2", "3", "4", "5":
-			index := int(keypress[0] - '1')
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9", "a", "b", "c", "d", "e", "f", "g", "h", "i":
+			index := strings.Index(quickKeys, keypress)
 			if index < len(m.list.Items()) {
 				m.list.Select(index)
 				i, ok := m.list.SelectedItem().(menuItem)
 				if ok {
-					switch string(i) {
-					case "search movie":
-						m.Choice = "Search a movie"
-					case "user profile":
-						m.Choice = "View a person's profile"
-					case "diary":
-						m.Choice = "Get diary of a person"
-					case "watchlist":
-						m.Choice = "Get Watchlist"
-					case "view lists":
-						m.Choice = "View Lists of Letterboxd"
-					default:
-						m.Choice = ""
-					}
+					m.Choice = menuChoice(i)
 					if m.Choice != "" {
 						return m, tea.Quit
 					}
@@ -386,20 +428,7 @@ func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			i, ok := m.list.SelectedItem().(menuItem)
 			if ok {
-				switch string(i) {
-				case "search movie":
-					m.Choice = "Search a movie"
-				case "user profile":
-					m.Choice = "View a person's profile"
-				case "diary":
-					m.Choice = "Get diary of a person"
-				case "watchlist":
-					m.Choice = "Get Watchlist"
-				case "view lists":
-					m.Choice = "View Lists of Letterboxd"
-				default:
-					m.Choice = ""
-				}
+				m.Choice = menuChoice(i)
 			}
 			if m.Choice != "" {
 				return m, tea.Quit
@@ -421,7 +450,7 @@ func (m MenuModel) renderHelpView() string {
 	keys := []string{
 		"↑ / k", "Navigate Up",
 		"↓ / j", "Navigate Down",
-		"1-5", "Quick Select Item",
+		quickKeyRange(len(m.list.Items())), "Quick Select Item",
 		"enter", "Confirm Selection",
 		"?", "Toggle This Help Menu",
 		"esc", "Close Help Menu / Go Back",
@@ -505,7 +534,7 @@ func (m MenuModel) View() string {
 
 	quickTips := lipgloss.JoinVertical(lipgloss.Left,
 		panelTitleStyle.Render("QUICK TIPS"),
-		lipgloss.JoinHorizontal(lipgloss.Left, tipBulletStyle.String(), " ", tipTextStyle.Render("Press [1-5] for quick navigation")),
+		lipgloss.JoinHorizontal(lipgloss.Left, tipBulletStyle.String(), " ", tipTextStyle.Render(fmt.Sprintf("Press [%s] for quick navigation", quickKeyRange(len(m.list.Items()))))),
 		lipgloss.JoinHorizontal(lipgloss.Left, tipBulletStyle.String(), " ", tipTextStyle.Render("Use ESC to return to menu")),
 		lipgloss.JoinHorizontal(lipgloss.Left, tipBulletStyle.String(), " ", tipTextStyle.Render("Press '?' for help")),
 	)
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/links"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type linkResolvedMsg struct {
+	link   string
+	target links.Target
+	err    error
+}
+
+func resolveLink(link string) tea.Cmd {
+	return func() tea.Msg {
+		target, err := links.Resolver{}.Resolve(context.Background(), link)
+		return linkResolvedMsg{link: link, target: target, err: err}
+	}
+}
+
+// slugTitle makes a readable name out of a slug until the real one has
+// been fetched, e.g. "christopher-nolan" becomes "Christopher Nolan".
+func slugTitle(slug string) string {
+	words := strings.Split(slug, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// openTarget returns the screen for a resolved link. Screens that have a
+// way back return to back.
+func openTarget(t links.Target, back tea.Model) (tea.Model, tea.Cmd) {
+	switch t.Kind {
+	case links.KindFilm:
+		return newFilmDetailsModel(Movie{Title: slugTitle(t.Slug), Slug: t.Slug}, back)
+	case links.KindReview:
+		next, cmd := newFilmDetailsModel(Movie{Title: slugTitle(t.Slug), Slug: t.Slug}, back)
+		next.startTab = tabReviews
+		next.startReview = t.Username
+		return next, cmd
+	case links.KindPerson:
+		next := NewFilmographyModel(Person{Name: slugTitle(t.Slug), Slug: t.Slug, Role: t.Role}, back)
+		return next, next.Init()
+	case links.KindList:
+		return newListModel(links.ListRef{Owner: t.Username, Slug: t.Slug})
+	case links.KindDiary:
+		return newUserDiaryModel(t.Username)
+	case links.KindWatchlist:
+		return newUserWatchlistModel(t.Username)
+	default:
+		return newUserProfileModel(t.Username)
+	}
+}
+
+// openLink hands a link pasted into some other screen's input over to an
+// OpenLinkModel. Esc from the opened screen returns to back.
+func openLink(link string, back tea.Model) (tea.Model, tea.Cmd) {
+	next := NewOpenLinkModelFor(link, back)
+	return next, next.Init()
+}
+
+// OpenLinkModel takes any letterboxd.com URL or boxd.it short link and
+// opens the screen for the film, profile, diary, review, list or person
+// it points to.
+type OpenLinkModel struct {
+	input     textinput.Model
+	spinner   spinner.Model
+	resolving bool
+	link      string
+	quitting  bool
+	err       error
+	back      tea.Model
+}
+
+func NewOpenLinkModel() OpenLinkModel {
+	ti := textinput.New()
+	ti.Placeholder = "https://letterboxd.com/... or https://boxd.it/..."
+	ti.Focus()
+	ti.CharLimit = 256
+	ti.Width = 50
+	ti.Prompt = "Link: "
+	ti.PromptStyle = listInputPromptStyle
+	ti.Cursor.Style = listInputCursorStyle
+	ti.TextStyle = listInputTextStyle
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	return OpenLinkModel{input: ti, spinner: sp}
+}
+
+// NewOpenLinkModelFor resolves link as soon as it starts.
+func NewOpenLinkModelFor(link string, back tea.Model) OpenLinkModel {
+	m := NewOpenLinkModel()
+	m.input.SetValue(link)
+	m.input.Blur()
+	m.link = link
+	m.resolving = true
+	m.back = back
+	return m
+}
+
+func (m OpenLinkModel) Init() tea.Cmd {
+	if m.resolving {
+		return tea.Batch(m.spinner.Tick, resolveLink(m.link))
+	}
+	return textinput.Blink
+}
+
+func (m OpenLinkModel) goBack() (tea.Model, tea.Cmd) {
+	if m.back != nil {
+		return m.back, nil
+	}
+	return NewMenuModel(), nil
+}
+
+func (m OpenLinkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			if m.err != nil && m.back == nil {
+				m.err = nil
+				m.input.Focus()
+				return m, textinput.Blink
+			}
+			return m.goBack()
+
+		case "enter":
+			if m.resolving || m.err != nil {
+				return m, nil
+			}
+			link := strings.TrimSpace(m.input.Value())
+			if link == "" {
+				return m, nil
+			}
+			m.link = link
+			m.resolving = true
+			m.input.Blur()
+			return m, tea.Batch(m.spinner.Tick, resolveLink(link))
+		}
+
+	case linkResolvedMsg:
+		if msg.link != m.link {
+			return m, nil
+		}
+		m.resolving = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		back := m.back
+		if back == nil {
+			back = NewOpenLinkModel()
+		}
+		return openTarget(msg.target, back)
+
+	case tea.WindowSizeMsg:
+		m.input.Width = min(msg.Width-12, 80)
+	}
+
+	if m.resolving {
+		m.spinner, cmd = m.spinner.Update(msg)
+	} else {
+		m.input, cmd = m.input.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m OpenLinkModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+	if m.resolving {
+		return fmt.Sprintf("\n\n   %s Opening %s...\n\n", m.spinner.View(), m.link)
+	}
+
+	title := listPageTitleStyle.Render("Open a Letterboxd Link")
+	inputBlock := lipgloss.JoinVertical(lipgloss.Left,
+		m.input.View(),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(strings.Repeat("─", m.input.Width+len(m.input.Prompt))),
+	)
+	help := listHelpStyle.Render("paste a link to a film, profile, diary, review, list or person (boxd.it links work too) and press enter")
+
+	final := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		inputBlock,
+		"\n\n\n",
+		help,
+	)
+	return lipgloss.NewStyle().Margin(1, 2).Render(final)
+}
@@ -0,0 +1,492 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	peoplePageTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#00A86B")).
+				Bold(true).
+				Margin(1, 0, 1, 0)
+
+	peopleInputPromptStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#4FC3F7"))
+
+	peopleInputCursorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#00A86B"))
+
+	peopleInputTextStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#00A86B"))
+
+	peopleHelpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("242"))
+)
+
+// Person is someone with a filmography page on Letterboxd. Role is the
+// page they are listed under, e.g. "actor" or "director". Film credits
+// also carry the character played or the crew department.
+type Person struct {
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	Role       string `json:"role"`
+	KnownFor   string `json:"known_for,omitempty"`
+	Character  string `json:"character,omitempty"`
+	Department string `json:"department,omitempty"`
+}
+
+// filmographyRoles are the role pages offered when switching roles on the
+// filmography screen.
+var filmographyRoles = []string{"actor", "director", "writer", "composer"}
+
+func roleLabel(role string) string {
+	if role == "" {
+		return ""
+	}
+	return strings.ToUpper(role[:1]) + strings.ReplaceAll(role[1:], "-", " ")
+}
+
+type searchPeopleResultMsg struct {
+	people []Person
+	err    error
+}
+
+type filmographyResultMsg struct {
+	person Person
+	films  []Movie
+	err    error
+}
+
+func callPythonSearchPeople(query string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := runPyExec("search_people", query)
+		if err != nil {
+			return searchPeopleResultMsg{err: err}
+		}
+		var people []Person
+		if err := json.Unmarshal(out, &people); err != nil {
+			return searchPeopleResultMsg{err: fmt.Errorf("failed to parse people search JSON: %w", err)}
+		}
+		return searchPeopleResultMsg{people: people}
+	}
+}
+
+func callPythonGetFilmography(person Person) tea.Cmd {
+	return func() tea.Msg {
+		out, err := runPyExec("get_filmography", person.Role, person.Slug)
+		if err != nil {
+			return filmographyResultMsg{person: person, err: err}
+		}
+		var result struct {
+			Person Person  `json:"person"`
+			Films  []Movie `json:"films"`
+		}
+		if err := json.Unmarshal(out, &result); err != nil {
+			return filmographyResultMsg{person: person, err: fmt.Errorf("failed to parse filmography JSON: %w", err)}
+		}
+		if result.Person.Name == "" {
+			result.Person = person
+		}
+		return filmographyResultMsg{person: result.Person, films: result.Films}
+	}
+}
+
+type PeopleModel struct {
+	input       textinput.Model
+	spinner     spinner.Model
+	table       table.Model
+	showSpinner bool
+	showTable   bool
+	submitted   bool
+	quitting    bool
+	err         error
+	people      []Person
+	baseStyle   lipgloss.Style
+	width       int
+}
+
+func NewPeopleModel() PeopleModel {
+	ti := textinput.New()
+	ti.Placeholder = "Enter an actor, director, writer or composer..."
+	ti.Focus()
+	ti.CharLimit = 128
+	ti.Width = 40
+	ti.Prompt = "Name: "
+	ti.PromptStyle = peopleInputPromptStyle
+	ti.Cursor.Style = peopleInputCursorStyle
+	ti.TextStyle = peopleInputTextStyle
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	return PeopleModel{
+		input:     ti,
+		spinner:   sp,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+	}
+}
+
+func (m PeopleModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m PeopleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "q":
+			if m.showTable {
+				m.quitting = true
+				return m, tea.Quit
+			}
+
+		case "esc":
+			if m.err != nil {
+				m.err = nil
+				m.showTable = false
+				m.submitted = false
+				m.input.Focus()
+				return m, nil
+			}
+			if m.showTable {
+				m.showTable = false
+				m.submitted = false
+				m.input.Focus()
+				return m, nil
+			}
+			return NewMenuModel(), nil
+
+		case "enter":
+			if !m.submitted {
+				if links.IsLink(m.input.Value()) {
+					return openLink(m.input.Value(), m)
+				}
+				m.submitted = true
+				m.showSpinner = true
+				cmds = append(cmds, m.spinner.Tick, callPythonSearchPeople(m.input.Value()))
+			} else if m.showTable {
+				cursor := m.table.Cursor()
+				if cursor < len(m.people) {
+					next := NewFilmographyModel(m.people[cursor], m)
+					return next, next.Init()
+				}
+			}
+		}
+
+	case searchPeopleResultMsg:
+		m.showSpinner = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.showTable = true
+		m.people = msg.people
+
+		rows := []table.Row{}
+		for _, p := range m.people {
+			rows = append(rows, table.Row{p.Name, roleLabel(p.Role), p.KnownFor})
+		}
+
+		columns := []table.Column{
+			{Title: "Name", Width: 30},
+			{Title: "Role", Width: 12},
+			{Title: "Known For", Width: 45},
+		}
+
+		t := table.New(
+			table.WithColumns(columns),
+			table.WithRows(rows),
+			table.WithFocused(true),
+			table.WithHeight(min(len(rows)+1, 15)),
+		)
+
+		s := table.DefaultStyles()
+		s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+		s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+		t.SetStyles(s)
+
+		m.table = t
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		if m.showTable {
+			m.table.SetWidth(msg.Width - 4)
+		}
+	}
+
+	if m.showSpinner {
+		m.spinner, cmd = m.spinner.Update(msg)
+		cmds = append(cmds, cmd)
+	} else if !m.showTable {
+		m.input, cmd = m.input.Update(msg)
+		cmds = append(cmds, cmd)
+	} else {
+		m.table, cmd = m.table.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m PeopleModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+
+	if m.showSpinner {
+		return fmt.Sprintf("\n\n   %s Searching for people matching '%s'...\n\n", m.spinner.View(), m.input.Value())
+	}
+
+	if m.showTable {
+		if len(m.people) == 0 {
+			return "\nNo people found.\n\n(Press 'esc' to go back)"
+		}
+		return m.baseStyle.Render(m.table.View()) + "\n(Use ↑/↓ to scroll, Enter to view filmography, Esc to go back)"
+	}
+
+	title := peoplePageTitleStyle.Render("Search People")
+	inputBlock := lipgloss.JoinVertical(lipgloss.Left,
+		m.input.View(),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(strings.Repeat("─", m.input.Width+len(m.input.Prompt))),
+	)
+	help := peopleHelpStyle.Render("type a name and press enter")
+
+	final := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		inputBlock,
+		"\n\n\n",
+		help,
+	)
+	return lipgloss.NewStyle().Margin(1, 2).Render(final)
+}
+
+// FilmographyModel lists the films of one person in one role. Esc returns
+// to the screen it was opened from.
+type FilmographyModel struct {
+	person       Person
+	spinner      spinner.Model
+	table        table.Model
+	loading      bool
+	quitting     bool
+	err          error
+	films        []Movie
+	back         tea.Model
+	exporter     exportPrompt
+	exportStatus exportStatus
+	baseStyle    lipgloss.Style
+	width        int
+}
+
+func NewFilmographyModel(person Person, back tea.Model) FilmographyModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	columns := []table.Column{
+		{Title: "Title", Width: 40},
+		{Title: "Year", Width: 6},
+		{Title: "Average", Width: 10},
+	}
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	exporter := newExportPrompt("e.g., exports/filmography.csv",
+		peopleInputPromptStyle, peopleInputCursorStyle, peopleInputTextStyle)
+
+	return FilmographyModel{
+		person:    person,
+		spinner:   sp,
+		table:     t,
+		loading:   true,
+		back:      back,
+		exporter:  exporter,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+	}
+}
+
+func (m FilmographyModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, callPythonGetFilmography(m.person))
+}
+
+func (m FilmographyModel) goBack() (tea.Model, tea.Cmd) {
+	if m.back != nil {
+		return m.back, nil
+	}
+	return NewMenuModel(), nil
+}
+
+func filmographyTable(person Person, films []Movie) export.Table {
+	t := export.Table{
+		Title: fmt.Sprintf("%s (%s)", person.Name, roleLabel(person.Role)),
+		Columns: []export.Column{
+			{Name: "Title"},
+			{Name: "Year", Numeric: true},
+			{Name: "Average", Numeric: true},
+			{Name: "Slug"},
+		},
+	}
+	for _, film := range films {
+		average := ""
+		if film.Rating > 0 {
+			average = fmt.Sprintf("%.2f", film.Rating)
+		}
+		t.Rows = append(t.Rows, []string{film.Title, fmt.Sprintf("%d", film.Year), average, film.Slug})
+	}
+	return t
+}
+
+func (m FilmographyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			return m.goBack()
+
+		case "enter":
+			cursor := m.table.Cursor()
+			if !m.loading && cursor < len(m.films) {
+				return newFilmDetailsModel(m.films[cursor], m)
+			}
+
+		case "r":
+			if !m.loading {
+				next := filmographyRoles[0]
+				for i, role := range filmographyRoles {
+					if role == m.person.Role {
+						next = filmographyRoles[(i+1)%len(filmographyRoles)]
+					}
+				}
+				m.person.Role = next
+				m.loading = true
+				m.err = nil
+				return m, tea.Batch(m.spinner.Tick, callPythonGetFilmography(m.person))
+			}
+
+		case "e":
+			if !m.loading && len(m.films) > 0 {
+				baseName := fmt.Sprintf("exports/%s_%s", m.person.Role, safeFileName(m.person.Slug))
+				return m, m.exporter.Open(filmographyTable(m.person, m.films), baseName)
+			}
+		}
+
+	case filmographyResultMsg:
+		m.loading = false
+		m.person = msg.person
+		m.err = msg.err
+		m.films = msg.films
+
+		rows := make([]table.Row, len(m.films))
+		for i, film := range m.films {
+			year, average := "", "–"
+			if film.Year > 0 {
+				year = fmt.Sprintf("%d", film.Year)
+			}
+			if film.Rating > 0 {
+				average = fmt.Sprintf("★ %.2f", film.Rating)
+			}
+			rows[i] = table.Row{film.Title, year, average}
+		}
+		m.table.SetRows(rows)
+		m.table.SetCursor(0)
+		return m, nil
+
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.table.SetWidth(msg.Width - 4)
+		m.exporter.input.Width = msg.Width - 20
+	}
+
+	if m.loading {
+		m.spinner, cmd = m.spinner.Update(msg)
+	} else {
+		m.table, cmd = m.table.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m FilmographyModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting filmography: %s", m.person.Name))
+	}
+	if m.loading {
+		return fmt.Sprintf("\n\n   %s Fetching %s filmography for '%s'...\n\n", m.spinner.View(), m.person.Role, m.person.Name)
+	}
+
+	title := peoplePageTitleStyle.Render(fmt.Sprintf("%s · %s", m.person.Name, roleLabel(m.person.Role)))
+	if m.err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Margin(0, 2).Render(title),
+			fmt.Sprintf("Error: %v", m.err),
+			"\n(Press 'r' to try another role, 'esc' to go back)",
+		)
+	}
+	if len(m.films) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Margin(0, 2).Render(title),
+			fmt.Sprintf("No films listed as %s.", m.person.Role),
+			"\n(Press 'r' to try another role, 'esc' to go back)",
+		)
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Margin(0, 2).Render(title),
+		m.baseStyle.Render(m.table.View()),
+		fmt.Sprintf("%d films", len(m.films)),
+		"(Enter to view film, 'r' to switch role, 'e' to export, Esc to go back)",
+	)
+	if exportMsg := m.exportStatus.View("Filmography"); exportMsg != "" {
+		view += "\n" + exportMsg
+	}
+	return view
+}
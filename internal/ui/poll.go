@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/anshonweb/letterbox-cli/internal/poll"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// pollAddr is where polls started from the interface are served. If
+	// the port is taken any free one is used; the screen shows which.
+	pollAddr = ":8080"
+
+	// maxPollFilms bounds how many films a poll started from a table
+	// offers, taking them from the top.
+	maxPollFilms = 12
+)
+
+var (
+	pollURLStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#4FC3F7")).Bold(true)
+	pollFilmStyle = lipgloss.NewStyle().Inline(true).Width(40).MaxWidth(40)
+)
+
+type pollChangedMsg struct{}
+
+// waitForVotes reports the next change to p, or nothing once ctx is
+// done so the wait does not outlive the screen.
+func waitForVotes(ctx context.Context, p *poll.Poll) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-p.Changed():
+			return pollChangedMsg{}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// PollModel serves a poll and shows the votes as they come in, then the
+// ranked-choice result once voting is closed.
+type PollModel struct {
+	poll     *poll.Poll
+	server   *poll.Server
+	quitting bool
+	err      error
+	back     tea.Model
+
+	// ctx is cancelled when the screen is left, ending the wait for votes.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewPollModel shows p, which server is serving. Leaving the screen
+// stops the server.
+func NewPollModel(p *poll.Poll, server *poll.Server, back tea.Model) PollModel {
+	ctx, cancel := context.WithCancel(context.Background())
+	return PollModel{poll: p, server: server, back: back, ctx: ctx, cancel: cancel}
+}
+
+// startPoll serves a poll over the first films, each offered once, and
+// opens its screen. It returns to back when done.
+func startPoll(title string, movies []Movie, back tea.Model) (tea.Model, tea.Cmd) {
+	var films []poll.Film
+	seen := map[string]bool{}
+	for _, m := range movies {
+		if len(films) == maxPollFilms {
+			break
+		}
+		if key := filmKey(m); !seen[key] {
+			seen[key] = true
+			films = append(films, poll.Film{Title: m.Title, Year: m.Year})
+		}
+	}
+	p := poll.New(title, films)
+	server, err := poll.Serve(p, pollAddr)
+	if err != nil {
+		server, err = poll.Serve(p, ":0")
+	}
+	if err != nil {
+		return PollModel{poll: p, err: err, back: back}, nil
+	}
+	next := NewPollModel(p, server, back)
+	return next, next.Init()
+}
+
+func (m PollModel) Init() tea.Cmd {
+	if m.ctx == nil {
+		return nil
+	}
+	return waitForVotes(m.ctx, m.poll)
+}
+
+// stop ends voting, shuts the server down and stops waiting for votes.
+func (m PollModel) stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.poll.Close()
+	if m.server != nil {
+		m.server.Close()
+	}
+}
+
+func (m PollModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.stop()
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			m.stop()
+			if m.back == nil {
+				m.quitting = true
+				return m, tea.Quit
+			}
+			return m.back, nil
+
+		case "c":
+			if m.err == nil {
+				m.poll.Close()
+			}
+			return m, nil
+		}
+
+	case pollChangedMsg:
+		return m, waitForVotes(m.ctx, m.poll)
+	}
+	return m, nil
+}
+
+func (m PollModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+
+	blocks := []string{filmsTitleStyle.Render(m.poll.Title)}
+
+	ballots := m.poll.Ballots()
+	if m.poll.Closed() {
+		blocks = append(blocks,
+			fmt.Sprintf("Voting closed with %d ballot(s).", len(ballots)),
+			"",
+			listFollowStyle.Render(m.poll.Summary()),
+		)
+	} else {
+		blocks = append(blocks, "Ask everyone to open:")
+		for _, u := range m.server.URLs() {
+			blocks = append(blocks, "  "+pollURLStyle.Render(u))
+		}
+	}
+
+	// First choices so far, as bars.
+	tally := m.poll.Tally()
+	most := 1
+	for _, c := range tally {
+		if c > most {
+			most = c
+		}
+	}
+	blocks = append(blocks, "", fmt.Sprintf("First choices (%d ballots):", len(ballots)))
+	for i, f := range m.poll.Films {
+		bar := histogramBarStyle.Render(strings.Repeat("█", tally[i]*20/most))
+		blocks = append(blocks, fmt.Sprintf("  %s %s %d", pollFilmStyle.Render(f.String()), bar, tally[i]))
+	}
+
+	if len(ballots) > 0 {
+		var voters []string
+		for _, b := range ballots {
+			voters = append(voters, b.Voter)
+		}
+		blocks = append(blocks, "", "Voted: "+strings.Join(voters, ", "))
+	}
+
+	help := "('c' to close voting and count, Esc to stop the poll)"
+	if m.poll.Closed() {
+		help = "(Esc to stop the poll)"
+	}
+	blocks = append(blocks, "", help)
+	return lipgloss.NewStyle().Margin(1, 2).Render(lipgloss.JoinVertical(lipgloss.Left, blocks...))
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/cache"
+	"github.com/anshonweb/letterbox-cli/internal/termimg"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Size of the poster on the Information tab, in cells. Letterboxd
+// posters are 2:3, which fills this box exactly.
+const (
+	posterCols = 20
+	posterRows = 15
+)
+
+// Size of the film still, which replaces the poster when toggled. Stills
+// are 16:9.
+const (
+	stillCols = 40
+	stillRows = 12
+)
+
+// Narrowest terminals the poster and the still are shown in.
+const (
+	posterMinWidth = 100
+	stillMinWidth  = 120
+)
+
+var imageProtocol = sync.OnceValue(termimg.Detect)
+
+type posterMsg struct {
+	url     string
+	picture termimg.Picture
+	err     error
+}
+
+// clearPictureMsg asks the root model to put seq in front of the next
+// view, so it reaches the terminal through the renderer.
+type clearPictureMsg struct{ seq string }
+
+// fetchPoster loads the image at url, poster or still, sized to fit cols
+// by rows cells.
+func fetchPoster(url string, cols, rows int) tea.Cmd {
+	return func() tea.Msg {
+		pic, err := loadPoster(url, cols, rows)
+		return posterMsg{url: url, picture: pic, err: err}
+	}
+}
+
+func loadPoster(url string, cols, rows int) (termimg.Picture, error) {
+	data, err := posterData(url)
+	if err != nil {
+		return termimg.Picture{}, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return termimg.Picture{}, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return termimg.Render(img, cols, rows, imageProtocol())
+}
+
+// posterData returns the image from the disk cache, downloading it on a
+// miss. Failing to cache is not an error; the image is just fetched again
+// next time.
+func posterData(url string) ([]byte, error) {
+	store, err := cache.Open("posters", 30*24*time.Hour)
+	if err == nil {
+		if data, ok := store.Get(url); ok {
+			return data, nil
+		}
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download image: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+
+	if store != nil {
+		_ = store.Put(url, data)
+	}
+	return data, nil
+}
+
+// clearPicture removes p from the screen when leaving a model that showed
+// it, or when another picture takes its place, since the next view knows
+// nothing about it. The sequence is written by the renderer as part of a
+// frame; writing it from a command would race the renderer.
+func clearPicture(p termimg.Picture) tea.Cmd {
+	seq := p.Clear()
+	if seq == "" {
+		return nil
+	}
+	return func() tea.Msg { return clearPictureMsg{seq: seq} }
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// runPyExec locates the bundled Python executable called name, runs it with
+// args and returns its standard output. A {"error": "..."} object printed
+// by the script is turned into a Go error.
+func runPyExec(name string, args ...string) ([]byte, error) {
+	pyExecName := name
+	if runtime.GOOS == "windows" {
+		pyExecName += ".exe"
+	}
+
+	baseDir := ""
+	snapDir := os.Getenv("SNAP")
+	if snapDir != "" {
+		baseDir = snapDir
+	} else {
+		goExecPath, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("fatal: could not get executable path: %w", err)
+		}
+		baseDir = filepath.Dir(goExecPath)
+	}
+
+	pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
+
+	if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
+		wd, _ := os.Getwd()
+		arch := runtime.GOARCH
+		osDir := runtime.GOOS + "_" + arch
+		altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
+
+		if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
+			pyExecPath = altPyExecPath
+		} else {
+			return nil, fmt.Errorf("python executable not found at %s or %s",
+				filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
+				filepath.Join("project_root", "dist_py", osDir, pyExecName))
+		}
+	}
+
+	cmd := exec.Command(pyExecPath, args...)
+	out, err := cmd.Output()
+
+	if err != nil {
+		var errData map[string]string
+		if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
+			return nil, errors.New(errData["error"])
+		}
+		return nil, fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))
+	}
+	var maybeErr map[string]string
+	if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
+		return nil, errors.New(maybeErr["error"])
+	}
+	return out, nil
+}
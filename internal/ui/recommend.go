@@ -0,0 +1,480 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/cache"
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// maxSeeds bounds how many highly rated diary films are used to
+	// find recommendations, the most recently logged first.
+	maxSeeds = 40
+
+	similarMaxAge = 30 * 24 * time.Hour
+)
+
+// recommendRatings are the minimum ratings a diary film needs to seed
+// recommendations, in the order 'r' cycles through them.
+var recommendRatings = []float64{4, 4.5, 5, 3.5}
+
+// recommendation is a film suggested because it is similar to films the
+// user rated highly.
+type recommendation struct {
+	Movie
+	Score   float64
+	Because []DiaryEntry // highest rated first
+}
+
+// reason explains the pick by the one or two best rated films behind it.
+func (r recommendation) reason() string {
+	var parts []string
+	for _, e := range r.Because[:min(len(r.Because), 2)] {
+		parts = append(parts, fmt.Sprintf("%s %s", e.Title, starLabel(e.Rating)))
+	}
+	reason := "because you rated " + strings.Join(parts, " and ")
+	if extra := len(r.Because) - 2; extra > 0 {
+		reason += fmt.Sprintf(" (+%d more)", extra)
+	}
+	return reason
+}
+
+type recommendationsMsg struct {
+	username  string
+	minRating float64
+	diary     []DiaryEntry
+	watched   []LoggedFilm
+	recs      []recommendation
+	seeds     int
+	failed    int
+	err       error
+}
+
+// loadSimilarFilms returns the films Letterboxd lists as similar to slug,
+// from the disk cache when it can.
+func loadSimilarFilms(slug string) ([]Movie, error) {
+	var films []Movie
+	store, err := cache.Open("similar-films", similarMaxAge)
+	if err == nil {
+		if data, ok := store.Get(slug); ok && json.Unmarshal(data, &films) == nil {
+			return films, nil
+		}
+	}
+
+	out, err := runPyExec("get_similar_films", slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(out, &films); err != nil {
+		return nil, fmt.Errorf("failed to parse similar films JSON: %w", err)
+	}
+	if store != nil {
+		_ = store.Put(slug, out)
+	}
+	return films, nil
+}
+
+// recommendSeeds picks the diary films rated at least minRating, each
+// once at its best rating, most recently logged first.
+func recommendSeeds(diary []DiaryEntry, minRating float64) []DiaryEntry {
+	best := map[string]int{}
+	var seeds []DiaryEntry
+	for _, e := range diary {
+		if e.Slug == "" || e.Rating < minRating {
+			continue
+		}
+		if i, ok := best[e.Slug]; ok {
+			if e.Rating > seeds[i].Rating {
+				seeds[i].Rating = e.Rating
+			}
+			continue
+		}
+		best[e.Slug] = len(seeds)
+		seeds = append(seeds, e)
+	}
+	return seeds[:min(len(seeds), maxSeeds)]
+}
+
+// recommend scores every film similar to a seed that the user has not
+// logged in their diary or marked as watched. Each seed it is similar to
+// adds the seed's rating above 2.5 stars, so films recommended by several
+// favourites, and by better loved ones, come first.
+func recommend(diary []DiaryEntry, films []LoggedFilm, seeds []DiaryEntry, similar map[string][]Movie) []recommendation {
+	watched := map[string]bool{}
+	for _, e := range diary {
+		watched[filmKey(Movie{Title: e.Title, Year: e.Year, Slug: e.Slug})] = true
+	}
+	for _, f := range films {
+		watched[filmKey(Movie{Title: f.Title, Year: f.Year, Slug: f.Slug})] = true
+	}
+
+	byKey := map[string]*recommendation{}
+	for _, seed := range seeds {
+		for _, film := range similar[seed.Slug] {
+			key := filmKey(film)
+			if watched[key] {
+				continue
+			}
+			r, ok := byKey[key]
+			if !ok {
+				r = &recommendation{Movie: film}
+				byKey[key] = r
+			}
+			r.Score += seed.Rating - 2.5
+			r.Because = append(r.Because, seed)
+		}
+	}
+
+	recs := make([]recommendation, 0, len(byKey))
+	for _, r := range byKey {
+		sort.SliceStable(r.Because, func(i, j int) bool { return r.Because[i].Rating > r.Because[j].Rating })
+		recs = append(recs, *r)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].Title < recs[j].Title
+	})
+	return recs
+}
+
+// fetchRecommendations looks up the films similar to each seed, a few at
+// a time. The diary and the films the user has watched are fetched first
+// unless they are already known.
+func fetchRecommendations(username string, diary []DiaryEntry, watched []LoggedFilm, minRating float64) tea.Cmd {
+	return func() tea.Msg {
+		msg := recommendationsMsg{username: username, minRating: minRating, diary: diary, watched: watched}
+		if msg.diary == nil {
+			var err error
+			if msg.diary, err = loadDiary(username); err != nil {
+				msg.err = err
+				return msg
+			}
+		}
+		if msg.watched == nil {
+			var err error
+			if msg.watched, err = loadLoggedFilms(username); err != nil {
+				msg.err = err
+				return msg
+			}
+		}
+
+		seeds := recommendSeeds(msg.diary, minRating)
+		msg.seeds = len(seeds)
+		found := make([][]Movie, len(seeds))
+		msg.failed = forEachBounded(len(seeds), func(i int) error {
+			var err error
+			found[i], err = loadSimilarFilms(seeds[i].Slug)
+			return err
+		})
+		similar := make(map[string][]Movie, len(seeds))
+		for i, seed := range seeds {
+			similar[seed.Slug] = found[i]
+		}
+
+		if len(seeds) > 0 && msg.failed == len(seeds) {
+			msg.err = fmt.Errorf("could not fetch similar films for any of %d diary films", len(seeds))
+			return msg
+		}
+		msg.recs = recommend(msg.diary, msg.watched, seeds, similar)
+		return msg
+	}
+}
+
+func recommendationsTable(recs []recommendation, username string) export.Table {
+	t := export.Table{
+		Title: fmt.Sprintf("Recommendations for %s", username),
+		Columns: []export.Column{
+			{Name: "Score", Numeric: true},
+			{Name: "Title"},
+			{Name: "Year", Numeric: true},
+			{Name: "Because"},
+		},
+	}
+	for _, r := range recs {
+		var because []string
+		for _, e := range r.Because {
+			because = append(because, fmt.Sprintf("%s (%.1f)", e.Title, e.Rating))
+		}
+		t.Rows = append(t.Rows, []string{
+			fmt.Sprintf("%.1f", r.Score), r.Title, fmt.Sprintf("%d", r.Year), strings.Join(because, "; "),
+		})
+	}
+	return t
+}
+
+// RecommendModel suggests films for a user from the films similar to the
+// ones they rated highly in their diary.
+type RecommendModel struct {
+	input        textinput.Model
+	spinner      spinner.Model
+	table        table.Model
+	baseStyle    lipgloss.Style
+	loading      bool
+	showTable    bool
+	quitting     bool
+	err          error
+	username     string
+	minRating    float64
+	diary        []DiaryEntry
+	watched      []LoggedFilm
+	recs         []recommendation
+	seeds        int
+	failed       int
+	exporter     exportPrompt
+	exportStatus exportStatus
+}
+
+func NewRecommendModel() RecommendModel {
+	ti := textinput.New()
+	ti.Placeholder = "Enter Letterboxd username..."
+	ti.Focus()
+	ti.CharLimit = 128
+	ti.Width = 40
+	ti.Prompt = "Username: "
+	ti.PromptStyle = watchInputPromptStyle
+	ti.Cursor.Style = watchInputCursorStyle
+	ti.TextStyle = watchInputTextStyle
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Score", Width: 6},
+			{Title: "Title", Width: 36},
+			{Title: "Year", Width: 6},
+			{Title: "Why", Width: 60},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	exporter := newExportPrompt("e.g., recommendations.csv or exports/recommendations.csv",
+		watchInputPromptStyle, watchInputCursorStyle, watchInputTextStyle)
+
+	return RecommendModel{
+		input:     ti,
+		spinner:   sp,
+		table:     t,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+		minRating: recommendRatings[0],
+		exporter:  exporter,
+	}
+}
+
+func (m RecommendModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *RecommendModel) updateRows() {
+	rows := make([]table.Row, len(m.recs))
+	for i, r := range m.recs {
+		rows[i] = table.Row{fmt.Sprintf("%.1f", r.Score), r.Title, fmt.Sprintf("%d", r.Year), r.reason()}
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(0)
+}
+
+func (m RecommendModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			if m.err != nil || m.showTable {
+				m.err = nil
+				m.showTable = false
+				m.loading = false
+				m.diary = nil
+				m.watched = nil
+				m.exportStatus = exportStatus{}
+				m.input.Focus()
+				return m, textinput.Blink
+			}
+			return NewMenuModel(), nil
+
+		case "enter":
+			if m.showTable {
+				if i := m.table.Cursor(); i < len(m.recs) {
+					return newFilmDetailsModel(m.recs[i].Movie, m)
+				}
+				return m, nil
+			}
+			if m.loading {
+				return m, nil
+			}
+			value := strings.TrimSpace(m.input.Value())
+			if value == "" {
+				return m, nil
+			}
+			if links.IsLink(value) {
+				return openLink(value, m)
+			}
+			m.username = value
+			m.loading = true
+			m.input.Blur()
+			return m, tea.Batch(m.spinner.Tick, fetchRecommendations(m.username, nil, nil, m.minRating))
+		}
+
+		if m.showTable && !m.loading {
+			switch msg.String() {
+			case "q":
+				m.quitting = true
+				return m, tea.Quit
+			case "r":
+				m.minRating = recommendRatings[(indexOfRating(m.minRating)+1)%len(recommendRatings)]
+				m.loading = true
+				return m, tea.Batch(m.spinner.Tick, fetchRecommendations(m.username, m.diary, m.watched, m.minRating))
+			case "v":
+				if len(m.recs) > 0 {
+					movies := make([]Movie, len(m.recs))
+					for i, r := range m.recs {
+						movies[i] = r.Movie
+					}
+					return startPoll(fmt.Sprintf("What should we watch from @%s's recommendations?", m.username), movies, m)
+				}
+				return m, nil
+			case "e":
+				if len(m.recs) > 0 {
+					return m, m.exporter.Open(recommendationsTable(m.recs, m.username), "exports/recommendations_"+safeFileName(m.username))
+				}
+				return m, nil
+			}
+		}
+
+	case recommendationsMsg:
+		if msg.username != m.username || msg.minRating != m.minRating || !m.loading {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.diary = msg.diary
+		m.watched = msg.watched
+		m.recs = msg.recs
+		m.seeds = msg.seeds
+		m.failed = msg.failed
+		m.showTable = true
+		m.updateRows()
+		return m, nil
+
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.table.SetWidth(msg.Width - 4)
+		m.exporter.input.Width = msg.Width - 20
+	}
+
+	switch {
+	case m.loading:
+		m.spinner, cmd = m.spinner.Update(msg)
+	case m.showTable:
+		m.table, cmd = m.table.Update(msg)
+	default:
+		m.input, cmd = m.input.Update(msg)
+	}
+	return m, cmd
+}
+
+func indexOfRating(r float64) int {
+	for i, v := range recommendRatings {
+		if v == r {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m RecommendModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting recommendations for: %s", m.username))
+	}
+	if m.loading {
+		return fmt.Sprintf("\n\n   %s Finding films like the ones @%s rated %s or more...\n\n", m.spinner.View(), m.username, starLabel(m.minRating))
+	}
+
+	if m.showTable {
+		header := lipgloss.JoinVertical(lipgloss.Left,
+			filmsTitleStyle.Render(fmt.Sprintf("Recommended for @%s", m.username)),
+			filmsFilterStyle.Render(fmt.Sprintf("from %d diary films rated %s or more", m.seeds, starLabel(m.minRating))),
+		)
+		help := "(Enter to view film, 'r' minimum rating, 'v' start a poll, 'e' to export, Esc to go back)"
+
+		var body string
+		switch {
+		case m.seeds == 0:
+			body = fmt.Sprintf("No diary films rated %s or more to go on. Press 'r' to lower the bar.", starLabel(m.minRating))
+		case len(m.recs) == 0:
+			body = "Every similar film has already been logged."
+		default:
+			body = m.baseStyle.Render(m.table.View())
+		}
+		blocks := []string{header, body}
+		if m.failed > 0 {
+			blocks = append(blocks, fmt.Sprintf("(similar films unavailable for %d of them)", m.failed))
+		}
+		blocks = append(blocks, help)
+		view := lipgloss.JoinVertical(lipgloss.Left, blocks...)
+		if exportMsg := m.exportStatus.View("Recommendations"); exportMsg != "" {
+			view += "\n" + exportMsg
+		}
+		return view
+	}
+
+	title := watchlistPageTitleStyle.Render("Recommendations")
+	inputBlock := lipgloss.JoinVertical(lipgloss.Left,
+		m.input.View(),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(strings.Repeat("─", m.input.Width+len(m.input.Prompt))),
+	)
+	help := watchHelpStyle.Render("type a username to get films like the ones they rated highly, and press enter")
+
+	final := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		inputBlock,
+		"\n\n\n",
+		help,
+	)
+	return lipgloss.NewStyle().Margin(1, 2).Render(final)
+}
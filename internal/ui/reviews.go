@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	reviewSpoilerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FF9800")).
+				Italic(true)
+
+	reviewPreviewStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				PaddingLeft(2)
+)
+
+// reviewSorts are the orders the Reviews tab cycles through with 'o'.
+var reviewSorts = []string{"popular", "recent", "earliest", "highest", "lowest"}
+
+// reviewPreviewLines is how much of each review is shown in the list;
+// Enter opens the whole text.
+const reviewPreviewLines = 2
+
+// reviewsPage is one page of a film's reviews.
+type reviewsPage struct {
+	Reviews []Review `json:"reviews"`
+	Page    int      `json:"page"`
+	HasMore bool     `json:"has_more"`
+}
+
+type reviewsResultMsg struct {
+	slug   string
+	sort   string
+	page   int
+	result reviewsPage
+	err    error
+}
+
+func callPythonGetReviews(slug, sort string, page int) (reviewsPage, error) {
+	out, err := runPyExec("get_movie_reviews", slug, sort, strconv.Itoa(page))
+	if err != nil {
+		return reviewsPage{}, err
+	}
+	var result reviewsPage
+	if err := json.Unmarshal(out, &result); err != nil {
+		return reviewsPage{}, fmt.Errorf("failed to parse reviews JSON: %w", err)
+	}
+	return result, nil
+}
+
+func fetchReviews(slug, sort string, page int) tea.Cmd {
+	return func() tea.Msg {
+		result, err := callPythonGetReviews(slug, sort, page)
+		return reviewsResultMsg{slug: slug, sort: sort, page: page, result: result, err: err}
+	}
+}
+
+// loadReviews requests a page of reviews in the current sort order.
+// Responses for any other film, page or order are dropped when they
+// arrive.
+func (m *SearchModel) loadReviews(page int) tea.Cmd {
+	m.reviewPage = page
+	m.loadingReviews = true
+	m.reviewsErr = nil
+	m.reviewCursor = 0
+	m.revealed = map[int]bool{}
+	return fetchReviews(m.selectedMovie.Slug, reviewSorts[m.reviewSort], page)
+}
+
+func (m *SearchModel) moveReviewCursor(key string) {
+	switch key {
+	case "up", "k":
+		m.reviewCursor--
+	case "down", "j":
+		m.reviewCursor++
+	case "pgup", "home":
+		m.reviewCursor = 0
+	case "pgdown", "end":
+		m.reviewCursor = len(m.reviews) - 1
+	}
+	if m.reviewCursor >= len(m.reviews) {
+		m.reviewCursor = len(m.reviews) - 1
+	}
+	if m.reviewCursor < 0 {
+		m.reviewCursor = 0
+	}
+}
+
+// openSelectedReview reveals the selected review if it is a hidden
+// spoiler, and otherwise opens it in the reader.
+func (m *SearchModel) openSelectedReview() {
+	if m.reviewCursor >= len(m.reviews) {
+		return
+	}
+	if m.reviews[m.reviewCursor].Spoiler && !m.revealed[m.reviewCursor] {
+		m.revealed[m.reviewCursor] = true
+		return
+	}
+	r := m.reviews[m.reviewCursor]
+	m.openReader(reviewHeader(r), r.Text, r.URL)
+}
+
+// openReader shows text in the review reader under header, with a link
+// to url below it.
+func (m *SearchModel) openReader(header, text, url string) {
+	m.readerHeader, m.readerText, m.readerURL = header, text, url
+	m.reviewViewport = viewport.New(m.reviewWidth(), m.creditsHeight())
+	m.reviewViewport.SetContent(m.readerContent())
+	m.readingReview = true
+}
+
+func (m SearchModel) reviewWidth() int {
+	if m.width == 0 {
+		return 80
+	}
+	if w := m.width - 12; w > 20 {
+		return w
+	}
+	return 20
+}
+
+// readerContent wraps the reader's text to the current width.
+func (m SearchModel) readerContent() string {
+	text := lipgloss.NewStyle().Width(m.reviewWidth()).Render(m.readerText)
+	if m.readerURL != "" {
+		text += "\n\n" + movieSubtitleStyle.Render(m.readerURL)
+	}
+	return text
+}
+
+func reviewHeader(r Review) string {
+	name := r.DisplayName
+	if name == "" {
+		name = r.Author
+	}
+	parts := []string{movieAuthorStyle.Render(name)}
+	if r.Rating > 0 {
+		parts = append(parts, movieRatingStyle.Render(fmt.Sprintf("★ %.1f/5", r.Rating)))
+	}
+
+	var meta []string
+	if r.Date != "" {
+		meta = append(meta, r.Date)
+	}
+	meta = append(meta, fmt.Sprintf("♥ %s", formatLargeNumber(r.Likes)))
+	parts = append(parts, movieSubtitleStyle.Render(strings.Join(meta, " · ")))
+	return strings.Join(parts, " ")
+}
+
+// reviewPreview returns the first few wrapped lines of text, marking
+// where it was cut off.
+func reviewPreview(text string, width int) string {
+	lines := strings.Split(lipgloss.NewStyle().Width(width).Render(text), "\n")
+	if len(lines) <= reviewPreviewLines {
+		return strings.Join(lines, "\n")
+	}
+	return strings.Join(lines[:reviewPreviewLines], "\n") + " …"
+}
+
+func (m SearchModel) renderReviewsTab() string {
+	sortLine := movieSubtitleStyle.Render(fmt.Sprintf("sort: %s · page %d", reviewSorts[m.reviewSort], m.reviewPage))
+
+	if m.loadingReviews {
+		return sortLine + "\n\n" + fmt.Sprintf("Loading %s reviews...", reviewSorts[m.reviewSort])
+	}
+	if m.reviewsErr != nil {
+		return sortLine + "\n\n" + fmt.Sprintf("Could not load reviews: %v", m.reviewsErr)
+	}
+	if len(m.reviews) == 0 {
+		return sortLine + "\n\n" + "No reviews available."
+	}
+
+	// Each review takes a header, its preview and a blank line.
+	perScreen := m.creditsHeight() / (reviewPreviewLines + 2)
+	if perScreen < 1 {
+		perScreen = 1
+	}
+	start := m.reviewCursor - m.reviewCursor%perScreen
+	end := start + perScreen
+	if end > len(m.reviews) {
+		end = len(m.reviews)
+	}
+
+	blocks := []string{sortLine}
+	for i := start; i < end; i++ {
+		r := m.reviews[i]
+		prefix := "  "
+		if i == m.reviewCursor {
+			prefix = navActiveStyle.Render("→ ")
+		}
+
+		var body string
+		if r.Spoiler && !m.revealed[i] {
+			body = reviewPreviewStyle.Render(reviewSpoilerStyle.Render("⚠ This review contains spoilers. Press 's' or Enter to reveal."))
+		} else {
+			body = reviewPreviewStyle.Render(reviewPreview(r.Text, m.reviewWidth()-2))
+		}
+		blocks = append(blocks, prefix+reviewHeader(r)+"\n"+body)
+	}
+
+	position := fmt.Sprintf("%d/%d", m.reviewCursor+1, len(m.reviews))
+	if m.reviewsHasMore {
+		position += " · more pages"
+	}
+	blocks = append(blocks, movieSubtitleStyle.Render(position))
+	return strings.Join(blocks, "\n\n")
+}
@@ -6,17 +6,43 @@ import (
 
 type RootModel struct {
 	current tea.Model
+
+	// clear is a terminal sequence written ahead of the view until the
+	// next key press, used to remove pictures the current view no longer
+	// knows about.
+	clear string
 }
 
 func NewRootModel() RootModel {
 	return RootModel{current: NewMenuModel()}
 }
 
+// NewRootModelForLink starts on the screen that link points to.
+func NewRootModelForLink(link string) RootModel {
+	return RootModel{current: NewOpenLinkModelFor(link, nil)}
+}
+
 func (m RootModel) Init() tea.Cmd {
 	return m.current.Init()
 }
 
 func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(clearPictureMsg); ok {
+		m.clear = msg.seq
+		return m, nil
+	}
+
+	next, cmd := m.route(msg)
+	if root, ok := next.(RootModel); ok {
+		if _, key := msg.(tea.KeyMsg); !key {
+			root.clear = m.clear
+		}
+		return root, cmd
+	}
+	return next, cmd
+}
+
+func (m RootModel) route(msg tea.Msg) (tea.Model, tea.Cmd) {
 	newModel, cmd := m.current.Update(msg)
 
 	switch typed := newModel.(type) {
@@ -39,6 +65,25 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if typed.Choice == "Get diary of a person" {
 			return RootModel{current: NewDiaryModel()}, nil
 		}
+		if typed.Choice == "Search people" {
+			return RootModel{current: NewPeopleModel()}, nil
+		}
+		if typed.Choice == "Open a Letterboxd link" {
+			return RootModel{current: NewOpenLinkModel()}, nil
+		}
+		if typed.Choice == "Compare group watchlists" {
+			return RootModel{current: NewGroupWatchlistModel()}, nil
+		}
+		if typed.Choice == "Recommend films" {
+			return RootModel{current: NewRecommendModel()}, nil
+		}
+		if typed.Choice == "View friends' activity" {
+			return RootModel{current: NewActivityModel()}, nil
+		}
+		if typed.Choice == "View followed lists" {
+			next := NewFollowedListsModel()
+			return RootModel{current: next}, next.Init()
+		}
 		return RootModel{current: typed}, cmd
 
 	case SearchModel:
@@ -52,13 +97,43 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case WatchlistModel:
 		return RootModel{current: typed}, cmd
 
+	case WatchlistChangesModel:
+		return RootModel{current: typed}, cmd
+
+	case GroupWatchlistModel:
+		return RootModel{current: typed}, cmd
+
+	case PollModel:
+		return RootModel{current: typed}, cmd
+
+	case RecommendModel:
+		return RootModel{current: typed}, cmd
+
+	case ActivityModel:
+		return RootModel{current: typed}, cmd
+
+	case SocialModel:
+		return RootModel{current: typed}, cmd
+
 	case DiaryModel:
 		return RootModel{current: typed}, cmd
+
+	case PeopleModel:
+		return RootModel{current: typed}, cmd
+
+	case FilmographyModel:
+		return RootModel{current: typed}, cmd
+
+	case FilmsModel:
+		return RootModel{current: typed}, cmd
+
+	case OpenLinkModel:
+		return RootModel{current: typed}, cmd
 	}
 
 	return m, cmd
 }
 
 func (m RootModel) View() string {
-	return m.current.View()
+	return m.clear + m.current.View()
 }
@@ -3,16 +3,17 @@ package ui
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
+	"github.com/anshonweb/letterbox-cli/internal/termimg"
 	"github.com/charmbracelet/bubbles/paginator"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -126,15 +127,21 @@ var (
 )
 
 type Movie struct {
-	Title    string `json:"title"`
-	Year     int    `json:"year"`
-	Slug     string `json:"slug"`
-	Director string `json:"director"`
+	Title    string  `json:"title"`
+	Year     int     `json:"year"`
+	Slug     string  `json:"slug"`
+	Director string  `json:"director"`
+	Rating   float64 `json:"rating,omitempty"`
 }
 type Review struct {
-	Author string  `json:"author"`
-	Text   string  `json:"text"`
-	Rating float64 `json:"rating"`
+	Author      string  `json:"author"`
+	DisplayName string  `json:"display_name,omitempty"`
+	Text        string  `json:"text"`
+	Rating      float64 `json:"rating"`
+	Date        string  `json:"date,omitempty"`
+	Likes       int     `json:"likes,omitempty"`
+	Spoiler     bool    `json:"spoiler,omitempty"`
+	URL         string  `json:"url,omitempty"`
 }
 
 type Provider struct {
@@ -156,6 +163,8 @@ type MovieDetails struct {
 	Rating      float64        `json:"rating"`
 	Description string         `json:"description"`
 	URL         string         `json:"url"`
+	Poster      string         `json:"poster"`
+	Still       string         `json:"still"`
 	Reviews     []Review       `json:"reviews"`
 	Runtime     string         `json:"runtime"`
 	Providers   []Provider     `json:"providers"`
@@ -167,11 +176,42 @@ type MovieDetails struct {
 	ReviewCount int            `json:"review_count"`
 	Lists       int            `json:"lists"`
 	Similar     []SimilarMovie `json:"similar"`
+	People      []Person       `json:"people"`
+	Histogram   []RatingBucket `json:"histogram"`
+
+	// Credits is the full cast, in billing order, followed by the crew
+	// grouped by department.
+	Credits           []Person `json:"credits"`
+	Studios           []string `json:"studios"`
+	Countries         []string `json:"countries"`
+	Languages         []string `json:"languages"`
+	AlternativeTitles []string `json:"alternative_titles"`
+}
+
+// Tabs of the film details view, in display order.
+const (
+	tabInfo = iota
+	tabCast
+	tabStats
+	tabReviews
+	tabFriends
+	tabSimilar
+	tabProviders
+)
+
+var detailsTabs = []string{"Information", "Cast & Crew", "Stats", "Reviews", "Friends", "Similar", "Where to Watch"}
+
+// searchPage is one page of film search results.
+type searchPage struct {
+	Movies  []Movie `json:"movies"`
+	Page    int     `json:"page"`
+	HasMore bool    `json:"has_more"`
 }
 
 type searchResultMsg struct {
-	movies []Movie
-	err    error
+	run  int
+	page searchPage
+	err  error
 }
 
 type detailsResultMsg struct {
@@ -193,17 +233,58 @@ type SearchModel struct {
 	selectedMovie    Movie
 	movieDetails     MovieDetails
 	movies           []Movie
+	query            searchQuery
+	searchRun        int
+	page             int
+	hasMore          bool
+	loadingMore      bool
+	emptyPages       int
+	err              error
 	baseStyle        lipgloss.Style
 	tabs             []string
 	activeTab        int
+	startTab         int
+	startReview      string // whose review to open once details load
 	width            int
+	height           int
+	creditCursor     int
+	creditOffset     int
+	reviews          []Review
+	reviewSort       int
+	reviewPage       int
+	reviewsHasMore   bool
+	loadingReviews   bool
+	reviewsErr       error
+	reviewCursor     int
+	revealed         map[int]bool
+	readingReview    bool
+	readerHeader     string
+	readerText       string
+	readerURL        string
+	reviewViewport   viewport.Model
+	friendRatings    []memberFilm
+	friendsSlug      string
+	loadingFriends   bool
+	friendsErr       error
+	friendsFollowing int
+	friendsPolled    int
+	friendsFailed    int
+	friendCursor     int
+	poster           *termimg.Picture
+	still            *termimg.Picture
+	showStill        bool
+	exporter         exportPrompt
+	exportStatus     exportStatus
+	pickingPerson    bool
+	personCursor     int
+	back             tea.Model
 }
 
 func NewSearchModel() SearchModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter movie name..."
 	ti.Focus()
-	ti.CharLimit = 64
+	ti.CharLimit = 128
 	ti.Width = 40
 
 	ti.Prompt = "Query: "
@@ -221,118 +302,48 @@ func NewSearchModel() SearchModel {
 	p.ActiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Render("•")
 	p.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("•")
 
+	exporter := newExportPrompt("e.g., exports/film.md",
+		searchInputPromptStyle, searchInputCursorStyle, searchInputTextStyle)
+
 	return SearchModel{
 		input:            ti,
 		spinner:          sp,
 		similarPaginator: p,
+		exporter:         exporter,
 		baseStyle:        lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
 	}
 }
 
-func callPythonSearch(query string) ([]Movie, error) {
-	pyExecName := "search_movie"
-	if runtime.GOOS == "windows" {
-		pyExecName += ".exe"
-	}
-
-	baseDir := ""
-	snapDir := os.Getenv("SNAP")
-	if snapDir != "" {
-		baseDir = snapDir
-	} else {
-		goExecPath, err := os.Executable()
-		if err != nil {
-			return nil, fmt.Errorf("fatal: could not get executable path: %w", err)
-		}
-		baseDir = filepath.Dir(goExecPath)
-	}
-
-	pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
-
-	if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
-		wd, _ := os.Getwd()
-		arch := runtime.GOARCH
-		osDir := runtime.GOOS + "_" + arch
-		altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
-
-		if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
-			pyExecPath = altPyExecPath
-		} else {
-			return nil, fmt.Errorf("python executable not found at %s or %s",
-				filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
-				filepath.Join("project_root", "dist_py", osDir, pyExecName))
-		}
-	}
-
-	cmd := exec.Command(pyExecPath, query)
-	out, err := cmd.Output()
+// maxEmptySearchPages bounds how many consecutive pages are fetched when
+// the filters reject every result on them.
+const maxEmptySearchPages = 5
 
+func callPythonSearch(query string, page int) (searchPage, error) {
+	out, err := runPyExec("search_movie", query, strconv.Itoa(page))
 	if err != nil {
-		var errData map[string]string
-		if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
-			return nil, fmt.Errorf(errData["error"])
-		}
-		return nil, fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))
-	}
-	var maybeErr map[string]string
-	if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
-		return nil, fmt.Errorf(maybeErr["error"])
+		return searchPage{}, err
 	}
-	var movies []Movie
-	if err := json.Unmarshal(out, &movies); err != nil {
-		return nil, fmt.Errorf("failed to parse movie search JSON: %w", err)
+	var result searchPage
+	if err := json.Unmarshal(out, &result); err != nil {
+		return searchPage{}, fmt.Errorf("failed to parse movie search JSON: %w", err)
 	}
-	return movies, nil
+	return result, nil
 }
 
-func callPythonGetDetails(slug string) (MovieDetails, error) {
-	pyExecName := "get_movie_details"
-	if runtime.GOOS == "windows" {
-		pyExecName += ".exe"
-	}
-
-	baseDir := ""
-	snapDir := os.Getenv("SNAP")
-	if snapDir != "" {
-		baseDir = snapDir
-	} else {
-		goExecPath, err := os.Executable()
-		if err != nil {
-			return MovieDetails{}, fmt.Errorf("fatal: could not get executable path: %w", err)
-		}
-		baseDir = filepath.Dir(goExecPath)
-	}
-
-	pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
-
-	if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
-		wd, _ := os.Getwd()
-		arch := runtime.GOARCH
-		osDir := runtime.GOOS + "_" + arch
-		altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
-
-		if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
-			pyExecPath = altPyExecPath
-		} else {
-			return MovieDetails{}, fmt.Errorf("python executable not found at %s or %s",
-				filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
-				filepath.Join("project_root", "dist_py", osDir, pyExecName))
+func searchMoviesPage(run int, query string, page int) tea.Cmd {
+	return func() tea.Msg {
+		result, err := callPythonSearch(query, page)
+		if result.Page == 0 {
+			result.Page = page
 		}
+		return searchResultMsg{run: run, page: result, err: err}
 	}
+}
 
-	cmd := exec.Command(pyExecPath, slug)
-	out, err := cmd.Output()
-
+func callPythonGetDetails(slug string) (MovieDetails, error) {
+	out, err := runPyExec("get_movie_details", slug)
 	if err != nil {
-		var errData map[string]string
-		if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
-			return MovieDetails{}, fmt.Errorf(errData["error"])
-		}
-		return MovieDetails{}, fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))
-	}
-	var maybeErr map[string]string
-	if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
-		return MovieDetails{}, fmt.Errorf(maybeErr["error"])
+		return MovieDetails{}, err
 	}
 	var details MovieDetails
 	if err := json.Unmarshal(out, &details); err != nil {
@@ -341,6 +352,27 @@ func callPythonGetDetails(slug string) (MovieDetails, error) {
 	return details, nil
 }
 
+// newFilmDetailsModel opens the details view for movie straight away. Esc
+// from the details returns to back.
+func newFilmDetailsModel(movie Movie, back tea.Model) (SearchModel, tea.Cmd) {
+	m := NewSearchModel()
+	m.back = back
+	m.submitted = true
+	m.showTable = true
+	m.selectedMovie = movie
+	m.loadingDetails = true
+	m.showSpinner = true
+	m.input.Blur()
+	return m, tea.Batch(m.spinner.Tick, fetchMovieDetails(movie.Slug))
+}
+
+func fetchMovieDetails(slug string) tea.Cmd {
+	return func() tea.Msg {
+		details, err := callPythonGetDetails(slug)
+		return detailsResultMsg{details, err}
+	}
+}
+
 func (m SearchModel) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -349,22 +381,96 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	if m.readingReview {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "esc", "q", "enter":
+				m.readingReview = false
+				return m, nil
+			}
+		case tea.WindowSizeMsg:
+			m.width, m.height = msg.Width, msg.Height
+			m.reviewViewport.Width = m.reviewWidth()
+			m.reviewViewport.Height = m.creditsHeight()
+			m.reviewViewport.SetContent(m.readerContent())
+			return m, nil
+		}
+		m.reviewViewport, cmd = m.reviewViewport.Update(msg)
+		return m, cmd
+	}
+
+	if m.pickingPerson {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "esc", "p":
+				m.pickingPerson = false
+			case "up", "k":
+				if m.personCursor > 0 {
+					m.personCursor--
+				}
+			case "down", "j":
+				if m.personCursor < len(m.movieDetails.People)-1 {
+					m.personCursor++
+				}
+			case "enter":
+				m.pickingPerson = false
+				next := NewFilmographyModel(m.movieDetails.People[m.personCursor], m)
+				return next, next.Init()
+			}
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+c":
 			return m, tea.Quit
 
+		case "q":
+			if m.showTable || m.viewingDetails {
+				return m, tea.Quit
+			}
+
 		case "esc":
 			if m.loadingDetails {
 				return m, nil
 			}
+			if m.back != nil && (m.viewingDetails || m.err != nil) {
+				if pic := m.picture(); pic != nil {
+					return m.back, clearPicture(*pic)
+				}
+				return m.back, nil
+			}
+			if m.err != nil {
+				m.err = nil
+				m.showTable = false
+				m.submitted = false
+				m.input.Focus()
+				return m, nil
+			}
 			if m.viewingDetails {
 				m.viewingDetails = false
 				return m, nil
 			} else if m.showTable {
 				m.showTable = false
 				m.submitted = false
+				// Pages still on their way belong to the abandoned search.
+				m.searchRun++
+				m.loadingMore = false
 				m.input.Focus()
 				return m, nil
 			} else {
@@ -373,111 +479,265 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			if !m.submitted {
+				if links.IsLink(m.input.Value()) {
+					return openLink(m.input.Value(), m)
+				}
+				query, err := parseSearchQuery(m.input.Value())
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
 				m.submitted = true
 				m.showSpinner = true
-				cmds = append(cmds, m.spinner.Tick, func() tea.Msg {
-					movies, err := callPythonSearch(m.input.Value())
-					return searchResultMsg{movies, err}
-				})
+				m.query = query
+				m.movies = nil
+				m.searchRun++
+				m.page = 1
+				m.hasMore = false
+				m.loadingMore = false
+				m.emptyPages = 0
+				cmds = append(cmds, m.spinner.Tick, searchMoviesPage(m.searchRun, query.Text, 1))
+			} else if m.viewingDetails && m.activeTab == tabReviews {
+				m.openSelectedReview()
+				return m, nil
+			} else if m.viewingDetails && m.activeTab == tabFriends {
+				m.openSelectedFriendReview()
+				return m, nil
+			} else if m.viewingDetails && m.activeTab == tabCast {
+				credits := m.movieDetails.Credits
+				if m.creditCursor < len(credits) && credits[m.creditCursor].Slug != "" {
+					next := NewFilmographyModel(credits[m.creditCursor], m)
+					return next, next.Init()
+				}
 			} else if m.showTable && !m.viewingDetails {
 				cursor := m.table.Cursor()
 				if len(m.movies) > cursor {
 					m.selectedMovie = m.movies[cursor]
 					m.loadingDetails = true
 					m.showSpinner = true
-					cmds = append(cmds, m.spinner.Tick, func() tea.Msg {
-						details, err := callPythonGetDetails(m.selectedMovie.Slug)
-						return detailsResultMsg{details, err}
-					})
+					cmds = append(cmds, m.spinner.Tick, fetchMovieDetails(m.selectedMovie.Slug))
 				}
 			}
 
 		case "left", "h":
-			if m.viewingDetails && m.activeTab != 2 {
-				m.activeTab--
-				if m.activeTab < 0 {
-					m.activeTab = len(m.tabs) - 1
-				}
-				return m, nil
+			if m.viewingDetails && m.activeTab != tabSimilar {
+				return m, m.switchTab(-1)
 			}
 
 		case "right", "l":
-			if m.viewingDetails && m.activeTab != 2 {
-				m.activeTab = (m.activeTab + 1) % len(m.tabs)
+			if m.viewingDetails && m.activeTab != tabSimilar {
+				return m, m.switchTab(1)
+			}
+
+		case "up", "k", "down", "j", "pgup", "pgdown", "home", "end":
+			if m.viewingDetails && m.activeTab == tabCast {
+				m.moveCreditCursor(msg.String())
+				return m, nil
+			}
+			if m.viewingDetails && m.activeTab == tabReviews {
+				m.moveReviewCursor(msg.String())
+				return m, nil
+			}
+			if m.viewingDetails && m.activeTab == tabFriends {
+				m.moveFriendCursor(msg.String())
+				return m, nil
+			}
+
+		case "s":
+			if m.viewingDetails && m.activeTab == tabReviews && m.reviewCursor < len(m.reviews) {
+				m.revealed[m.reviewCursor] = !m.revealed[m.reviewCursor]
+				return m, nil
+			}
+
+		case "o":
+			if m.viewingDetails && m.activeTab == tabReviews {
+				m.reviewSort = (m.reviewSort + 1) % len(reviewSorts)
+				return m, m.loadReviews(1)
+			}
+
+		case "n":
+			if m.viewingDetails && m.activeTab == tabReviews && m.reviewsHasMore && !m.loadingReviews {
+				return m, m.loadReviews(m.reviewPage + 1)
+			}
+
+		case "b":
+			if m.viewingDetails && m.activeTab == tabReviews && m.reviewPage > 1 && !m.loadingReviews {
+				return m, m.loadReviews(m.reviewPage - 1)
+			}
+
+		case "i":
+			if m.viewingDetails && m.activeTab == tabInfo && appConfig().Images && m.movieDetails.Still != "" {
+				return m, m.toggleStill()
+			}
+
+		case "p":
+			if m.viewingDetails && len(m.movieDetails.People) > 0 {
+				m.pickingPerson = true
+				m.personCursor = 0
 				return m, nil
 			}
 
+		case "v":
+			if m.showTable && !m.viewingDetails && !m.showSpinner && len(m.movies) > 0 {
+				return startPoll(fmt.Sprintf("What should we watch from the results for '%s'?", m.query.Text), m.movies, m)
+			}
+
+		case "e":
+			if m.viewingDetails {
+				baseName := fmt.Sprintf("exports/film_%s", safeFileName(m.selectedMovie.Slug))
+				return m, m.exporter.OpenDocument(movieDocument(m.movieDetails), baseName)
+			}
+
 		case "shift+tab":
 			if m.viewingDetails {
-				m.activeTab--
-				if m.activeTab < 0 {
-					m.activeTab = len(m.tabs) - 1
-				}
-				return m, nil
+				return m, m.switchTab(-1)
 			}
 
 		case "tab":
 			if m.viewingDetails {
-				m.activeTab = (m.activeTab + 1) % len(m.tabs)
-				return m, nil
+				return m, m.switchTab(1)
 			}
 		}
 
 	case searchResultMsg:
+		if msg.run != m.searchRun {
+			return m, nil
+		}
 		m.showSpinner = false
-		m.showTable = true
-		m.movies = msg.movies
-
-		rows := []table.Row{}
-		for i, movie := range m.movies {
-			rows = append(rows, table.Row{
-				fmt.Sprintf("%d", i+1),
-				movie.Title,
-				fmt.Sprintf("%d", movie.Year),
-				movie.Director,
-			})
+		m.loadingMore = false
+		if msg.err != nil {
+			if len(m.movies) == 0 {
+				m.err = msg.err
+			}
+			m.hasMore = false
+			return m, nil
 		}
 
-		columns := []table.Column{
-			{Title: "No", Width: 4},
-			{Title: "Title", Width: 40},
-			{Title: "Year", Width: 6},
-			{Title: "Director", Width: 25},
+		m.page = msg.page.Page
+		m.hasMore = msg.page.HasMore
+		added := 0
+		for _, movie := range msg.page.Movies {
+			if m.query.Match(movie) {
+				m.movies = append(m.movies, movie)
+				added++
+			}
 		}
 
-		t := table.New(
-			table.WithColumns(columns),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(min(len(rows)+1, 15)),
-		)
-
-		s := table.DefaultStyles()
-		s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
-		s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
-		t.SetStyles(s)
+		if !m.showTable {
+			m.showTable = true
+			m.table = newSearchTable()
+			if m.width > 0 {
+				m.table.SetWidth(m.width - 4)
+			}
+		}
+		m.updateSearchRows()
 
-		m.table = t
+		// Filters can reject a whole page; keep going for a while so the
+		// table is not left empty while matches exist further on.
+		if added == 0 {
+			m.emptyPages++
+		} else {
+			m.emptyPages = 0
+		}
+		if m.hasMore && (len(m.movies) == 0 || added == 0) && m.emptyPages < maxEmptySearchPages {
+			return m, m.loadNextPage()
+		}
 		return m, nil
 
 	case detailsResultMsg:
 		m.loadingDetails = false
 		m.showSpinner = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
 		m.viewingDetails = true
 		m.movieDetails = msg.details
-
-		m.tabs = []string{"Information", "Reviews", "Similar", "Where to Watch"}
-		m.activeTab = 0
+		m.poster = nil
+		m.still = nil
+		m.showStill = false
+
+		m.tabs = detailsTabs
+		m.activeTab = tabInfo
+		m.creditCursor = 0
+		m.creditOffset = 0
+		m.reviews = nil
+		m.reviewSort = 0
+		m.reviewPage = 0
+		m.reviewsErr = nil
+		m.friendsSlug = ""
+		m.friendRatings = nil
 
 		m.similarPaginator.SetTotalPages(len(m.movieDetails.Similar))
 		m.similarPaginator.Page = 0
+
+		// Links to a review open it in the reader, over the Reviews tab.
+		cmds = append(cmds, m.switchTab(m.startTab))
+		m.startTab = tabInfo
+		if m.startReview != "" {
+			cmds = append(cmds, fetchLinkedReview(m.startReview, m.selectedMovie.Slug))
+			m.startReview = ""
+		}
+		if appConfig().Images && m.movieDetails.Poster != "" {
+			cmds = append(cmds, fetchPoster(m.movieDetails.Poster, posterCols, posterRows))
+		}
+		return m, tea.Batch(cmds...)
+
+	case posterMsg:
+		// Without a poster the details simply stay text-only.
+		if msg.err != nil {
+			return m, nil
+		}
+		switch msg.url {
+		case m.movieDetails.Poster:
+			m.poster = &msg.picture
+		case m.movieDetails.Still:
+			m.still = &msg.picture
+		}
+		return m, nil
+
+	case reviewsResultMsg:
+		if msg.slug != m.selectedMovie.Slug || msg.sort != reviewSorts[m.reviewSort] || msg.page != m.reviewPage {
+			return m, nil
+		}
+		m.loadingReviews = false
+		if msg.err != nil {
+			m.reviewsErr = msg.err
+			return m, nil
+		}
+		m.reviews = msg.result.Reviews
+		m.reviewsHasMore = msg.result.HasMore
+		return m, nil
+
+	case linkedReviewMsg:
+		if msg.slug == m.selectedMovie.Slug {
+			m.openLinkedReview(msg)
+		}
+		return m, nil
+
+	case friendRatingsMsg:
+		if msg.slug != m.friendsSlug {
+			return m, nil
+		}
+		m.loadingFriends = false
+		m.friendsErr = msg.err
+		m.friendRatings = msg.ratings
+		m.friendsFollowing = msg.following
+		m.friendsPolled = msg.polled
+		m.friendsFailed = msg.failed
+		return m, nil
+
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
 		return m, nil
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
+		m.height = msg.Height
 		if m.showTable {
 			m.table.SetWidth(msg.Width - 4)
 		}
+		m.exporter.input.Width = msg.Width - 20
 	}
 
 	if m.showSpinner {
@@ -489,9 +749,14 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	} else if !m.viewingDetails {
 		m.table, cmd = m.table.Update(msg)
 		cmds = append(cmds, cmd)
+
+		if m.table.Cursor() >= len(m.movies)-3 && !m.loadingMore && m.hasMore {
+			m.emptyPages = 0
+			cmds = append(cmds, m.loadNextPage())
+		}
 	}
 
-	if m.viewingDetails && m.activeTab == 2 {
+	if m.viewingDetails && m.activeTab == tabSimilar {
 		m.similarPaginator, cmd = m.similarPaginator.Update(msg)
 		cmds = append(cmds, cmd)
 	}
@@ -499,6 +764,142 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// movieDocument collects everything shown across the details tabs into a
+// single exportable document.
+func movieDocument(d MovieDetails) export.Document {
+	doc := export.Document{
+		Title: fmt.Sprintf("%s (%d)", d.Title, d.Year),
+		Data:  d,
+	}
+
+	doc.Sections = append(doc.Sections, export.Section{
+		Heading: "Information",
+		Fields: []export.Field{
+			{Name: "Director", Value: d.Director},
+			{Name: "Runtime", Value: d.Runtime},
+			{Name: "Genres", Value: strings.Join(d.Genres, ", ")},
+			{Name: "Rating", Value: fmt.Sprintf("%.1f/5", d.Rating)},
+			{Name: "Cast", Value: strings.Join(d.Cast, ", ")},
+			{Name: "Members", Value: formatLargeNumber(d.Members)},
+			{Name: "Fans", Value: formatLargeNumber(d.Fans)},
+			{Name: "Likes", Value: formatLargeNumber(d.Likes)},
+			{Name: "Reviews", Value: formatLargeNumber(d.ReviewCount)},
+			{Name: "Lists", Value: formatLargeNumber(d.Lists)},
+			{Name: "Letterboxd", Value: d.URL},
+		},
+	})
+	if d.Tagline != "" {
+		doc.Sections = append(doc.Sections, export.Section{Heading: "Tagline", Text: fmt.Sprintf("> %s", d.Tagline)})
+	}
+	doc.Sections = append(doc.Sections, export.Section{Heading: "Synopsis", Text: d.Description})
+
+	if len(d.Credits) > 0 {
+		var credits []string
+		for _, c := range d.Credits {
+			credits = append(credits, fmt.Sprintf("%s: %s", c.Name, creditDescription(c)))
+		}
+		doc.Sections = append(doc.Sections, export.Section{
+			Heading: "Cast & Crew",
+			Fields: []export.Field{
+				{Name: "Studios", Value: strings.Join(d.Studios, ", ")},
+				{Name: "Countries", Value: strings.Join(d.Countries, ", ")},
+				{Name: "Languages", Value: strings.Join(d.Languages, ", ")},
+				{Name: "Also known as", Value: strings.Join(d.AlternativeTitles, ", ")},
+			},
+			Items: credits,
+		})
+	}
+
+	if total := ratingCount(d.Histogram); total > 0 {
+		var buckets []string
+		for _, b := range d.Histogram {
+			buckets = append(buckets, fmt.Sprintf("%s: %d (%.0f%%)", starLabel(b.Rating), b.Count, float64(b.Count)*100/float64(total)))
+		}
+		doc.Sections = append(doc.Sections, export.Section{
+			Heading: "Ratings",
+			Fields:  []export.Field{{Name: "Ratings", Value: formatLargeNumber(total)}},
+			Items:   buckets,
+		})
+	}
+
+	var reviews []string
+	for _, r := range d.Reviews {
+		reviews = append(reviews, fmt.Sprintf("**%s** ★ %.1f/5\n%s", r.Author, r.Rating, r.Text))
+	}
+	doc.Sections = append(doc.Sections, export.Section{Heading: "Reviews", Items: reviews})
+
+	var similar []string
+	for _, s := range d.Similar {
+		similar = append(similar, fmt.Sprintf("%s ★ %.1f/5", s.Name, s.Rating))
+	}
+	doc.Sections = append(doc.Sections, export.Section{Heading: "Similar", Items: similar})
+
+	var providers []string
+	for _, p := range d.Providers {
+		providers = append(providers, fmt.Sprintf("%s (%s): %s", p.Name, p.Type, p.Link))
+	}
+	doc.Sections = append(doc.Sections, export.Section{Heading: "Where to Watch", Items: providers})
+
+	return doc
+}
+
+func newSearchTable() table.Model {
+	columns := []table.Column{
+		{Title: "No", Width: 4},
+		{Title: "Title", Width: 40},
+		{Title: "Year", Width: 6},
+		{Title: "Director", Width: 25},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+	return t
+}
+
+func (m *SearchModel) updateSearchRows() {
+	rows := make([]table.Row, len(m.movies))
+	for i, movie := range m.movies {
+		year := ""
+		if movie.Year > 0 {
+			year = fmt.Sprintf("%d", movie.Year)
+		}
+		rows[i] = table.Row{
+			fmt.Sprintf("%d", i+1),
+			movie.Title,
+			year,
+			movie.Director,
+		}
+	}
+	m.table.SetRows(rows)
+	m.table.SetHeight(min(len(rows)+1, 15))
+}
+
+// switchTab moves delta tabs along the details view, loading the reviews
+// and friends' ratings the first time their tab is shown.
+func (m *SearchModel) switchTab(delta int) tea.Cmd {
+	m.activeTab = (m.activeTab + delta + len(m.tabs)) % len(m.tabs)
+	if m.activeTab == tabReviews && m.reviewPage == 0 {
+		return m.loadReviews(1)
+	}
+	if m.activeTab == tabFriends && m.friendsSlug != m.selectedMovie.Slug {
+		return m.loadFriends()
+	}
+	return nil
+}
+
+// loadNextPage fetches the page after the last one received.
+func (m *SearchModel) loadNextPage() tea.Cmd {
+	m.loadingMore = true
+	return searchMoviesPage(m.searchRun, m.query.Text, m.page+1)
+}
+
 func formatLargeNumber(n int) string {
 	if n > 1_000_000 {
 		return fmt.Sprintf("%.1fM", float64(n)/1_000_000.0)
@@ -588,21 +989,11 @@ func (m SearchModel) renderMovieInfo() string {
 		"",
 	)
 
-	return docStyle.Render(finalRender)
-}
-
-func (m SearchModel) renderMovieReviews() string {
-	d := m.movieDetails
-	if len(d.Reviews) == 0 {
-		return "No reviews available."
-	}
-	lines := []string{}
-	for _, r := range d.Reviews {
-		coloredAuthor := movieAuthorStyle.Render(r.Author)
-		coloredRating := movieRatingStyle.Render(fmt.Sprintf("★ %.1f/5", r.Rating))
-		lines = append(lines, fmt.Sprintf("• %s %s\n%s", coloredAuthor, coloredRating, r.Text))
+	if m.posterFits() {
+		finalRender = lipgloss.JoinHorizontal(lipgloss.Top, m.picture().Placeholder(), "   ", finalRender)
 	}
-	return strings.Join(lines, "\n\n")
+
+	return docStyle.Render(finalRender)
 }
 
 func (m SearchModel) renderSimilarTab() string {
@@ -629,6 +1020,20 @@ func (m SearchModel) renderSimilarTab() string {
 	return strings.Join(similarBlocks, "\n\n") + paginatorView
 }
 
+func (m SearchModel) renderPersonPicker() string {
+	lines := []string{synopsisHeaderStyle.Render("open a filmography"), ""}
+	for i, p := range m.movieDetails.People {
+		line := fmt.Sprintf("%s  %s", p.Name, movieSubtitleStyle.Render(roleLabel(p.Role)))
+		if i == m.personCursor {
+			line = navActiveStyle.Render("→ ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m SearchModel) renderProviders() string {
 	p := m.movieDetails.Providers
 	if len(p) == 0 {
@@ -646,15 +1051,75 @@ func (m SearchModel) renderProviders() string {
 	return strings.Join(lines, "\n\n")
 }
 
+// picture is the image shown on the Information tab: the still when it
+// has been toggled on, the poster otherwise. It is nil until loaded.
+func (m SearchModel) picture() *termimg.Picture {
+	if m.showStill {
+		return m.still
+	}
+	return m.poster
+}
+
+// posterFits reports whether the picture is loaded and the terminal is
+// wide enough to show it beside the information.
+func (m SearchModel) posterFits() bool {
+	minWidth := posterMinWidth
+	if m.showStill {
+		minWidth = stillMinWidth
+	}
+	return m.picture() != nil && m.width >= minWidth
+}
+
+// toggleStill swaps the poster for the film still or back, fetching the
+// still the first time. The picture being replaced is cleared, since a
+// graphics image outlives the text drawn over it.
+func (m *SearchModel) toggleStill() tea.Cmd {
+	var cmds []tea.Cmd
+	if pic := m.picture(); pic != nil {
+		cmds = append(cmds, clearPicture(*pic))
+	}
+	m.showStill = !m.showStill
+	if m.showStill && m.still == nil {
+		cmds = append(cmds, fetchPoster(m.movieDetails.Still, stillCols, stillRows))
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m SearchModel) View() string {
+	view := m.view()
+	pic := m.picture()
+	if pic == nil {
+		return view
+	}
+	if m.posterFits() && m.viewingDetails && m.activeTab == tabInfo &&
+		!m.exporter.active && !m.readingReview && !m.pickingPerson {
+		return pic.Composite(view)
+	}
+	return pic.Clear() + view
+}
+
+func (m SearchModel) view() string {
 	if m.quitting {
 		return "Goodbye!"
 	}
 
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting film: %s", m.movieDetails.Title))
+	}
+
 	if m.loadingDetails {
 		return fmt.Sprintf("\n\n   %s Fetching details for '%s'...\n\n", m.spinner.View(), m.selectedMovie.Title)
 	}
 
+	if m.viewingDetails && m.readingReview {
+		return SearchBorderBox.Render(m.readerHeader+"\n\n"+m.reviewViewport.View()) +
+			fmt.Sprintf("\n%3.f%%  (Use ↑/↓ to scroll, Esc to close)", m.reviewViewport.ScrollPercent()*100)
+	}
+
+	if m.viewingDetails && m.pickingPerson {
+		return SearchBorderBox.Render(m.renderPersonPicker()) + "\n(Use ↑/↓ to choose, Enter to open filmography, Esc to cancel)"
+	}
+
 	if m.viewingDetails {
 		var renderedTabs []string
 		for i, t := range m.tabs {
@@ -670,26 +1135,50 @@ func (m SearchModel) View() string {
 
 		var content string
 		switch m.activeTab {
-		case 0:
+		case tabInfo:
 			content = m.renderMovieInfo()
-		case 1:
-			content = m.renderMovieReviews()
-		case 2:
+		case tabCast:
+			content = m.renderCredits()
+		case tabStats:
+			content = m.renderFilmStats()
+		case tabReviews:
+			content = m.renderReviewsTab()
+		case tabFriends:
+			content = m.renderFriendsTab()
+		case tabSimilar:
 			content = m.renderSimilarTab()
-		case 3:
+		case tabProviders:
 			content = m.renderProviders()
 		}
 
 		full := fmt.Sprintf("%s\n\n%s", tabsRow, content)
 
-		helpText := "\n(Use ←/→ to switch tabs, ESC to go back)"
-		if m.activeTab == 2 {
-			helpText = "\n(Use ←/→ to change page, Tab to switch tabs, ESC to go back)"
+		helpText := "\n(Use ←/→ to switch tabs, 'p' for people, 'e' to export, ESC to go back)"
+		switch m.activeTab {
+		case tabInfo:
+			if appConfig().Images && m.movieDetails.Still != "" {
+				helpText = "\n(Use ←/→ to switch tabs, 'i' to show the still or poster, 'p' for people, 'e' to export, ESC to go back)"
+			}
+		case tabCast:
+			helpText = "\n(Use ↑/↓ to scroll, Enter to open filmography, ←/→ to switch tabs, 'e' to export, ESC to go back)"
+		case tabReviews:
+			helpText = "\n(Use ↑/↓ to choose, Enter to read, 's' to reveal spoilers, 'o' to sort, 'n'/'b' for next/previous page, ←/→ to switch tabs, ESC to go back)"
+		case tabFriends:
+			helpText = "\n(Use ↑/↓ to choose, Enter to read a review, ←/→ to switch tabs, 'e' to export, ESC to go back)"
+		case tabSimilar:
+			helpText = "\n(Use ←/→ to change page, Tab to switch tabs, 'p' for people, 'e' to export, ESC to go back)"
+		}
+		if exportMsg := m.exportStatus.View("Film"); exportMsg != "" {
+			helpText += "\n" + exportMsg
 		}
 
 		return SearchBorderBox.Render(full) + helpText
 	}
 
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+
 	if m.showSpinner {
 		return fmt.Sprintf("\n\n   %s Searching for '%s'...\n\n", m.spinner.View(), m.input.Value())
 	}
@@ -700,7 +1189,7 @@ func (m SearchModel) View() string {
 			m.input.View(),
 			lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(strings.Repeat("─", m.input.Width+len(m.input.Prompt))),
 		)
-		help := searchHelpStyle.Render("type a movie name and press enter")
+		help := searchHelpStyle.Render("type a movie name and press enter\nfilters: year:1999, year:1990-1999, director:nolan")
 
 		final := lipgloss.JoinVertical(lipgloss.Left,
 			title,
@@ -711,7 +1200,22 @@ func (m SearchModel) View() string {
 		return lipgloss.NewStyle().Margin(1, 2).Render(final)
 	}
 
-	return m.baseStyle.Render(m.table.View()) + "\n(Enter to view details, Esc to go back)"
+	status := ""
+	switch {
+	case m.loadingMore:
+		status = searchHelpStyle.Render(fmt.Sprintf("Loading page %d...", m.page+1))
+	case len(m.movies) == 0 && m.query.HasFilters():
+		status = searchHelpStyle.Render("No films matched the filters.")
+	case len(m.movies) == 0:
+		status = searchHelpStyle.Render("No films found.")
+	case !m.hasMore:
+		status = searchHelpStyle.Render(fmt.Sprintf("%d films, end of results", len(m.movies)))
+	}
+	if status != "" {
+		status = "\n" + status
+	}
+
+	return m.baseStyle.Render(m.table.View()) + status + "\n(Enter to view details, 'v' start a poll, Esc to go back)"
 }
 
 func min(a, b int) int {
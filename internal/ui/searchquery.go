@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// searchQuery is a film search split into the text sent to Letterboxd and
+// the filters applied to the results locally.
+//
+//	heat year:1995
+//	alien year:1979-1986 director:scott
+//	solaris director:"andrei tarkovsky"
+//
+// Year ranges may be open-ended ("year:2000-" or "year:-1970").
+type searchQuery struct {
+	Text     string
+	YearFrom int
+	YearTo   int
+	Director string
+}
+
+func parseSearchQuery(raw string) (searchQuery, error) {
+	var q searchQuery
+	var words []string
+
+	for _, tok := range splitQueryTokens(raw) {
+		key, value, found := strings.Cut(tok, ":")
+		if !found || value == "" {
+			words = append(words, tok)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "year", "y":
+			from, to, err := parseYearRange(value)
+			if err != nil {
+				return searchQuery{}, err
+			}
+			q.YearFrom, q.YearTo = from, to
+		case "director", "dir", "d":
+			q.Director = strings.ToLower(value)
+		default:
+			words = append(words, tok)
+		}
+	}
+
+	q.Text = strings.Join(words, " ")
+	if q.Text == "" {
+		return searchQuery{}, fmt.Errorf("enter a title to search for, filters only narrow the results")
+	}
+	return q, nil
+}
+
+// splitQueryTokens splits on spaces but keeps double-quoted values, such as
+// director:"sofia coppola", together.
+func splitQueryTokens(raw string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ' ' && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+func parseYearRange(value string) (int, int, error) {
+	from, to, isRange := strings.Cut(value, "-")
+	if !isRange {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid year %q", value)
+		}
+		return year, year, nil
+	}
+
+	var yearFrom, yearTo int
+	var err error
+	if from != "" {
+		if yearFrom, err = strconv.Atoi(from); err != nil {
+			return 0, 0, fmt.Errorf("invalid year %q", from)
+		}
+	}
+	if to != "" {
+		if yearTo, err = strconv.Atoi(to); err != nil {
+			return 0, 0, fmt.Errorf("invalid year %q", to)
+		}
+	}
+	if yearFrom != 0 && yearTo != 0 && yearFrom > yearTo {
+		return 0, 0, fmt.Errorf("year range %q ends before it starts", value)
+	}
+	return yearFrom, yearTo, nil
+}
+
+// HasFilters reports whether any local filter is set.
+func (q searchQuery) HasFilters() bool {
+	return q.YearFrom != 0 || q.YearTo != 0 || q.Director != ""
+}
+
+// Match reports whether movie passes the query's filters.
+func (q searchQuery) Match(movie Movie) bool {
+	if q.YearFrom != 0 && movie.Year < q.YearFrom {
+		return false
+	}
+	if q.YearTo != 0 && (movie.Year == 0 || movie.Year > q.YearTo) {
+		return false
+	}
+	if q.Director != "" && !strings.Contains(strings.ToLower(movie.Director), q.Director) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"sync"
+
+	"github.com/anshonweb/letterbox-cli/internal/config"
+)
+
+// appConfig returns the user's settings, loaded on first use. A config
+// file that cannot be read leaves the defaults in place.
+var appConfig = sync.OnceValue(func() config.Config {
+	cfg, _ := config.Load()
+	return cfg
+})
@@ -0,0 +1,434 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/store"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Views of the follower analysis, in display order.
+const (
+	socialMutuals = iota
+	socialNotFollowingBack
+	socialFans
+	socialFollowed
+	socialUnfollowed
+)
+
+var socialViews = []string{"Mutuals", "Not following back", "Fans", "New followers", "Unfollowed"}
+
+// followerSnapshot is who followed a user at one moment.
+type followerSnapshot struct {
+	Username  string    `json:"username"`
+	TakenAt   time.Time `json:"taken_at"`
+	Followers []Friend  `json:"followers"`
+}
+
+// followerChanges is who started and stopped following a user since an
+// earlier snapshot.
+type followerChanges struct {
+	Since      time.Time
+	Followed   []Friend
+	Unfollowed []Friend
+}
+
+// socialGraph splits a user's following and followers into who follows
+// whom back.
+type socialGraph struct {
+	Mutuals          []Friend // follow each other
+	NotFollowingBack []Friend // followed by the user, not following them
+	Fans             []Friend // following the user, not followed by them
+}
+
+type socialMsg struct {
+	username  string
+	following []Friend
+	followers []Friend
+	changes   *followerChanges
+	err       error
+	// snapshotErr is kept apart from err: the lists are still worth
+	// showing when the snapshot cannot be saved.
+	snapshotErr error
+}
+
+func followerStore() (*store.Store, error) {
+	return store.Open("followers")
+}
+
+func followerKeys(people []Friend) map[string]bool {
+	keys := make(map[string]bool, len(people))
+	for _, p := range people {
+		keys[strings.ToLower(p.Username)] = true
+	}
+	return keys
+}
+
+// missingFrom returns the people in a that are not in b, in a's order.
+func missingFrom(a, b []Friend) []Friend {
+	in := followerKeys(b)
+	var missing []Friend
+	for _, p := range a {
+		if !in[strings.ToLower(p.Username)] {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+func analyseSocial(following, followers []Friend) socialGraph {
+	followedBy := followerKeys(followers)
+	var g socialGraph
+	for _, p := range following {
+		if followedBy[strings.ToLower(p.Username)] {
+			g.Mutuals = append(g.Mutuals, p)
+		} else {
+			g.NotFollowingBack = append(g.NotFollowingBack, p)
+		}
+	}
+	g.Fans = missingFrom(followers, following)
+	return g
+}
+
+// saveFollowerSnapshot records username's followers now and returns who
+// followed and unfollowed since the latest earlier snapshot, or nil if
+// this is the first. Nothing is saved when the followers are the same as
+// in that snapshot.
+func saveFollowerSnapshot(username string, followers []Friend) (*followerChanges, error) {
+	s, err := followerStore()
+	if err != nil {
+		return nil, err
+	}
+	times, err := snapshotTimes(s, username)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes *followerChanges
+	if len(times) > 0 {
+		var previous followerSnapshot
+		key := snapshotPrefix(username) + times[len(times)-1].Format(snapshotTimeFormat)
+		if _, err := s.Load(key, &previous); err != nil {
+			return nil, err
+		}
+		changes = &followerChanges{
+			Since:      previous.TakenAt,
+			Followed:   missingFrom(followers, previous.Followers),
+			Unfollowed: missingFrom(previous.Followers, followers),
+		}
+		if len(changes.Followed) == 0 && len(changes.Unfollowed) == 0 {
+			return changes, nil
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	snap := followerSnapshot{Username: username, TakenAt: now, Followers: followers}
+	return changes, s.Save(snapshotPrefix(username)+now.Format(snapshotTimeFormat), snap)
+}
+
+// fetchSocial fetches both of username's lists at once and snapshots the
+// followers.
+func fetchSocial(username string) tea.Cmd {
+	return func() tea.Msg {
+		msg := socialMsg{username: username}
+		var followingErr, followersErr error
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			msg.following, followingErr = loadFollows(username, "following")
+		}()
+		go func() {
+			defer wg.Done()
+			msg.followers, followersErr = loadFollows(username, "followers")
+		}()
+		wg.Wait()
+
+		if followingErr != nil {
+			msg.err = followingErr
+			return msg
+		}
+		if followersErr != nil {
+			msg.err = followersErr
+			return msg
+		}
+		msg.changes, msg.snapshotErr = saveFollowerSnapshot(username, msg.followers)
+		return msg
+	}
+}
+
+// SocialModel compares who a user follows with who follows them, and
+// shows who followed or unfollowed them since the last check.
+type SocialModel struct {
+	spinner      spinner.Model
+	table        table.Model
+	baseStyle    lipgloss.Style
+	loading      bool
+	quitting     bool
+	err          error
+	username     string
+	following    []Friend
+	followers    []Friend
+	graph        socialGraph
+	changes      *followerChanges
+	snapshotErr  error
+	view         int
+	exporter     exportPrompt
+	exportStatus exportStatus
+	back         tea.Model
+}
+
+func NewSocialModel(username string, back tea.Model) SocialModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
+
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Username", Width: 30},
+			{Title: "Name", Width: 40},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	exporter := newExportPrompt("e.g., mutuals.csv or exports/followers.csv",
+		watchInputPromptStyle, watchInputCursorStyle, watchInputTextStyle)
+
+	return SocialModel{
+		spinner:   sp,
+		table:     t,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+		loading:   true,
+		username:  username,
+		exporter:  exporter,
+		back:      back,
+	}
+}
+
+func (m SocialModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, fetchSocial(m.username))
+}
+
+func (m SocialModel) goBack() (tea.Model, tea.Cmd) {
+	if m.back != nil {
+		return m.back, nil
+	}
+	return NewMenuModel(), nil
+}
+
+// people returns who is in the current view.
+func (m SocialModel) people() []Friend {
+	switch m.view {
+	case socialMutuals:
+		return m.graph.Mutuals
+	case socialNotFollowingBack:
+		return m.graph.NotFollowingBack
+	case socialFans:
+		return m.graph.Fans
+	case socialFollowed:
+		if m.changes != nil {
+			return m.changes.Followed
+		}
+	case socialUnfollowed:
+		if m.changes != nil {
+			return m.changes.Unfollowed
+		}
+	}
+	return nil
+}
+
+// describe says what the current view lists.
+func (m SocialModel) describe() string {
+	switch m.view {
+	case socialMutuals:
+		return fmt.Sprintf("people @%s follows who follow them back", m.username)
+	case socialNotFollowingBack:
+		return fmt.Sprintf("people @%s follows who do not follow them back", m.username)
+	case socialFans:
+		return fmt.Sprintf("followers @%s does not follow", m.username)
+	case socialFollowed:
+		if m.changes == nil {
+			return "first check, so there is nothing to compare with yet"
+		}
+		return "started following since " + m.changes.Since.Local().Format("2 Jan 2006 15:04")
+	default:
+		if m.changes == nil {
+			return "first check, so there is nothing to compare with yet"
+		}
+		return "stopped following since " + m.changes.Since.Local().Format("2 Jan 2006 15:04")
+	}
+}
+
+func (m *SocialModel) updateRows() {
+	people := m.people()
+	rows := make([]table.Row, len(people))
+	for i, p := range people {
+		rows[i] = table.Row{p.Username, p.Name}
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(0)
+}
+
+func socialTable(username, view string, people []Friend) export.Table {
+	t := export.Table{
+		Title:   fmt.Sprintf("%s: %s", username, view),
+		Columns: []export.Column{{Name: "Username"}, {Name: "Name"}},
+	}
+	for _, p := range people {
+		t.Rows = append(t.Rows, []string{p.Username, p.Name})
+	}
+	return t
+}
+
+func (m SocialModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "esc":
+			return m.goBack()
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "q":
+			m.quitting = true
+			return m, tea.Quit
+		case "right", "l", "tab":
+			m.view = (m.view + 1) % len(socialViews)
+			m.updateRows()
+			return m, nil
+		case "left", "h", "shift+tab":
+			m.view = (m.view - 1 + len(socialViews)) % len(socialViews)
+			m.updateRows()
+			return m, nil
+		case "enter":
+			if people := m.people(); m.table.Cursor() < len(people) {
+				return newUserProfileModel(people[m.table.Cursor()].Username)
+			}
+			return m, nil
+		case "e":
+			if people := m.people(); len(people) > 0 {
+				baseName := fmt.Sprintf("exports/%s_%s", safeFileName(m.username), safeFileName(strings.ToLower(socialViews[m.view])))
+				return m, m.exporter.Open(socialTable(m.username, socialViews[m.view], people), baseName)
+			}
+			return m, nil
+		}
+
+	case socialMsg:
+		if msg.username != m.username {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.following = msg.following
+		m.followers = msg.followers
+		m.graph = analyseSocial(msg.following, msg.followers)
+		m.changes = msg.changes
+		m.snapshotErr = msg.snapshotErr
+		m.updateRows()
+		return m, nil
+
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.table.SetWidth(msg.Width - 4)
+		m.exporter.input.Width = msg.Width - 20
+	}
+
+	if m.loading {
+		m.spinner, cmd = m.spinner.Update(msg)
+	} else {
+		m.table, cmd = m.table.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m SocialModel) tabsRow() string {
+	counts := []int{len(m.graph.Mutuals), len(m.graph.NotFollowingBack), len(m.graph.Fans), 0, 0}
+	if m.changes != nil {
+		counts[socialFollowed] = len(m.changes.Followed)
+		counts[socialUnfollowed] = len(m.changes.Unfollowed)
+	}
+	var tabs []string
+	for i, name := range socialViews {
+		style := navStyle
+		if i == m.view {
+			style = navActiveStyle
+		}
+		label := name
+		if m.changes != nil || i < socialFollowed {
+			label = fmt.Sprintf("%s (%d)", name, counts[i])
+		}
+		tabs = append(tabs, style.Render("→ "+label))
+	}
+	return strings.Join(tabs, "  ")
+}
+
+func (m SocialModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting %s for: %s", strings.ToLower(socialViews[m.view]), m.username))
+	}
+	if m.loading {
+		return fmt.Sprintf("\n\n   %s Fetching everyone @%s follows and everyone following them...\n\n", m.spinner.View(), m.username)
+	}
+
+	header := lipgloss.JoinVertical(lipgloss.Left,
+		filmsTitleStyle.Render(fmt.Sprintf("@%s follows %d and is followed by %d", m.username, len(m.following), len(m.followers))),
+		m.tabsRow(),
+		filmsFilterStyle.Render(m.describe()),
+	)
+	help := "(←/→ to switch views, Enter to open a profile, 'e' to export, Esc to go back)"
+
+	body := m.baseStyle.Render(m.table.View())
+	if len(m.people()) == 0 {
+		body = "Nobody here."
+	}
+	blocks := []string{header, body}
+	if m.snapshotErr != nil {
+		blocks = append(blocks, fmt.Sprintf("(could not save a snapshot of the followers: %v)", m.snapshotErr))
+	}
+	blocks = append(blocks, help)
+	view := lipgloss.JoinVertical(lipgloss.Left, blocks...)
+	if exportMsg := m.exportStatus.View("People"); exportMsg != "" {
+		view += "\n" + exportMsg
+	}
+	return view
+}
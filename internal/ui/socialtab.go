@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sahilm/fuzzy"
+)
+
+// socialListRows is how many people each list on the Social tab shows at
+// once.
+const socialListRows = 12
+
+// followsPage is one page of a user's following or followers list.
+type followsPage struct {
+	People  []Friend `json:"people"`
+	Page    int      `json:"page"`
+	HasMore bool     `json:"has_more"`
+}
+
+type followsPageMsg struct {
+	run      int
+	username string
+	kind     string
+	page     followsPage
+	err      error
+}
+
+func loadFollowsPage(username, kind string, page int) (followsPage, error) {
+	out, err := runPyExec("get_follows", username, kind, strconv.Itoa(page))
+	if err != nil {
+		return followsPage{}, err
+	}
+	var result followsPage
+	if err := json.Unmarshal(out, &result); err != nil {
+		return followsPage{}, fmt.Errorf("failed to parse %s JSON: %w", kind, err)
+	}
+	return result, nil
+}
+
+// fetchFollowsPage fetches one page. run tags it with the chain of pages
+// it belongs to, so pages from a chain that was started over are dropped.
+func fetchFollowsPage(run int, username, kind string, page int) tea.Cmd {
+	return func() tea.Msg {
+		result, err := loadFollowsPage(username, kind, page)
+		return followsPageMsg{run: run, username: username, kind: kind, page: result, err: err}
+	}
+}
+
+// friendNames lets fuzzy match against usernames and display names.
+type friendNames []Friend
+
+func (f friendNames) String(i int) string { return f[i].Username + " " + f[i].Name }
+func (f friendNames) Len() int            { return len(f) }
+
+// socialList is one of the two lists on a profile's Social tab. It is
+// fetched a page at a time, so large accounts come back complete, and
+// scrolls and searches independently of the other list.
+type socialList struct {
+	kind      string // "following" or "followers"
+	title     string
+	people    []Friend
+	seen      map[string]bool
+	total     int // from the profile; 0 if unknown
+	page      int
+	loading   bool
+	done      bool
+	err       error
+	search    textinput.Model
+	searching bool
+	visible   []Friend
+	cursor    int
+	offset    int
+}
+
+func newSocialList(kind, title string, total int) socialList {
+	si := textinput.New()
+	si.Placeholder = "fuzzy name search..."
+	si.CharLimit = 64
+	si.Width = 24
+	si.Prompt = "/ "
+	si.PromptStyle = watchInputPromptStyle
+	si.Cursor.Style = watchInputCursorStyle
+	si.TextStyle = watchInputTextStyle
+
+	return socialList{kind: kind, title: title, total: total, seen: map[string]bool{}, search: si}
+}
+
+// start fetches the first page; each page received asks for the next.
+func (l *socialList) start(run int, username string) tea.Cmd {
+	l.loading = true
+	return fetchFollowsPage(run, username, l.kind, 1)
+}
+
+func (l *socialList) receive(username string, msg followsPageMsg) tea.Cmd {
+	if msg.err != nil {
+		l.loading = false
+		l.err = msg.err
+		return nil
+	}
+	for _, p := range msg.page.People {
+		key := strings.ToLower(p.Username)
+		if !l.seen[key] {
+			l.seen[key] = true
+			l.people = append(l.people, p)
+		}
+	}
+	l.page = msg.page.Page
+	l.applySearch()
+	if msg.page.HasMore {
+		return fetchFollowsPage(msg.run, username, l.kind, msg.page.Page+1)
+	}
+	l.loading = false
+	l.done = true
+	// The count on the profile can lag behind the list itself.
+	l.total = len(l.people)
+	return nil
+}
+
+// applySearch narrows the list to the people matching the fuzzy search,
+// best matches first.
+func (l *socialList) applySearch() {
+	query := strings.TrimSpace(l.search.Value())
+	if query == "" {
+		l.visible = l.people
+	} else {
+		matches := fuzzy.FindFrom(query, friendNames(l.people))
+		l.visible = make([]Friend, len(matches))
+		for i, match := range matches {
+			l.visible[i] = l.people[match.Index]
+		}
+	}
+	l.move("")
+}
+
+// move moves the cursor for key and scrolls to keep it in view. An empty
+// key just keeps the cursor within the list.
+func (l *socialList) move(key string) {
+	switch key {
+	case "up", "k":
+		l.cursor--
+	case "down", "j":
+		l.cursor++
+	case "pgup":
+		l.cursor -= socialListRows
+	case "pgdown":
+		l.cursor += socialListRows
+	case "home":
+		l.cursor = 0
+	case "end":
+		l.cursor = len(l.visible) - 1
+	}
+	if l.cursor >= len(l.visible) {
+		l.cursor = len(l.visible) - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+	if l.cursor < l.offset {
+		l.offset = l.cursor
+	}
+	if l.cursor >= l.offset+socialListRows {
+		l.offset = l.cursor - socialListRows + 1
+	}
+}
+
+func (l socialList) selected() (Friend, bool) {
+	if l.cursor < len(l.visible) {
+		return l.visible[l.cursor], true
+	}
+	return Friend{}, false
+}
+
+// status says how far the list has loaded, or how many match the search.
+func (l socialList) status(barWidth int) string {
+	switch {
+	case l.err != nil:
+		return fmt.Sprintf("stopped after %d: %v", len(l.people), l.err)
+	case l.loading && l.total > 0:
+		return fmt.Sprintf("%s %d/%d", progressBar(len(l.people), l.total, barWidth), len(l.people), l.total)
+	case l.loading:
+		return fmt.Sprintf("%d loaded, page %d...", len(l.people), l.page+1)
+	case strings.TrimSpace(l.search.Value()) != "":
+		return fmt.Sprintf("%d of %d match", len(l.visible), len(l.people))
+	case len(l.people) > socialListRows:
+		return fmt.Sprintf("%d-%d of %d", l.offset+1, min(l.offset+socialListRows, len(l.visible)), len(l.people))
+	}
+	return ""
+}
+
+func (l socialList) View(focused bool, barWidth int) string {
+	title := l.title
+	if l.total > 0 {
+		title = fmt.Sprintf("%s (%d)", l.title, l.total)
+	}
+	if focused {
+		title = "▸ " + title
+	}
+	lines := []string{userSocialHeaderStyle.Render(title)}
+	if l.searching || l.search.Value() != "" {
+		lines = append(lines, l.search.View())
+	}
+
+	end := min(l.offset+socialListRows, len(l.visible))
+	var items []string
+	for i := l.offset; i < end; i++ {
+		p := l.visible[i]
+		name := p.Name
+		if !strings.EqualFold(p.Name, p.Username) {
+			name += " " + movieSubtitleStyle.Render("@"+p.Username)
+		}
+		prefix := "• "
+		if focused && i == l.cursor {
+			prefix = navActiveStyle.Render("→ ")
+		}
+		items = append(items, prefix+name)
+	}
+	switch {
+	case len(items) > 0:
+		lines = append(lines, userSocialListStyle.Render(strings.Join(items, "\n")))
+	case l.done && len(l.people) == 0:
+		lines = append(lines, userSocialListStyle.Render("Nobody yet."))
+	case !l.loading && len(l.people) > 0:
+		lines = append(lines, userSocialListStyle.Render("No matches."))
+	}
+	if status := l.status(barWidth); status != "" {
+		lines = append(lines, "", movieSubtitleStyle.Render(status))
+	}
+	return strings.Join(lines, "\n")
+}
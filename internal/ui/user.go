@@ -3,14 +3,12 @@ package ui
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"runtime"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
 	"github.com/charmbracelet/bubbles/paginator"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -92,8 +90,8 @@ type UserDetails struct {
 	Username     string       `json:"username"`
 	FilmsWatched int          `json:"films_watched"`
 	Bio          string       `json:"bio"`
-	Following    []string     `json:"following"`
-	Followers    []string     `json:"followers"`
+	Following    int          `json:"following_count"`
+	Followers    int          `json:"followers_count"`
 	Favorites    []string     `json:"favorites"`
 	LastWatched  string       `json:"last_watched"`
 	Reviews      []UserReview `json:"reviews"`
@@ -103,32 +101,52 @@ type UserDetails struct {
 	Location     string       `json:"location"`
 }
 
+// Tabs of the profile view, in display order.
+const (
+	userTabProfile = iota
+	userTabStats
+	userTabFavorites
+	userTabRecent
+	userTabReviews
+	userTabSocial
+)
+
 type userDetailsResultMsg struct {
 	details UserDetails
 	err     error
 }
 
 type UserModel struct {
-	input           textinput.Model
-	spinner         spinner.Model
-	paginator       paginator.Model
-	socialPaginator paginator.Model
-	loading         bool
-	submitted       bool
-	viewing         bool
-	quitting        bool
-	width           int
-	err             error
-	tabs            []string
-	activeTab       int
-	userDetails     UserDetails
+	input        textinput.Model
+	spinner      spinner.Model
+	paginator    paginator.Model
+	following    socialList
+	followers    socialList
+	socialFocus  int // 0 for following, 1 for followers
+	socialLoaded bool
+	socialRun    int // tags the page chains of the latest social lists
+	loading      bool
+	submitted    bool
+	viewing      bool
+	quitting     bool
+	width        int
+	err          error
+	tabs         []string
+	activeTab    int
+	userDetails  UserDetails
+	stats        userStats
+	loadingStats bool
+	statsLoaded  bool
+	statsErr     error
+	exporter     exportPrompt
+	exportStatus exportStatus
 }
 
 func NewUserModel() UserModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter a Letterboxd username..."
 	ti.Focus()
-	ti.CharLimit = 32
+	ti.CharLimit = 128
 	ti.Width = 30
 	ti.Prompt = ""
 
@@ -142,69 +160,33 @@ func NewUserModel() UserModel {
 	p.ActiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Render("•")
 	p.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("•")
 
-	socialP := paginator.New()
-	socialP.Type = paginator.Dots
-	socialP.PerPage = 5
-	socialP.ActiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Render("•")
-	socialP.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("•")
+	exporter := newExportPrompt("e.g., exports/profile.md",
+		searchInputPromptStyle, searchInputCursorStyle, searchInputTextStyle)
 
 	return UserModel{
-		input:           ti,
-		spinner:         sp,
-		paginator:       p,
-		socialPaginator: socialP,
-		tabs:            []string{"Profile", "Favorites", "Recent", "Reviews", "Social"},
+		input:     ti,
+		exporter:  exporter,
+		spinner:   sp,
+		paginator: p,
+		tabs:      []string{"Profile", "Stats", "Favorites", "Recent", "Reviews", "Social"},
 	}
 }
-func callPythonGetUserDetails(username string) tea.Cmd {
-	return func() tea.Msg {
-		pyExecName := "user_details"
-		if runtime.GOOS == "windows" {
-			pyExecName += ".exe"
-		}
-
-		baseDir := ""
-		snapDir := os.Getenv("SNAP")
-		if snapDir != "" {
-			baseDir = snapDir
-		} else {
-			goExecPath, err := os.Executable()
-			if err != nil {
-				return userDetailsResultMsg{err: fmt.Errorf("fatal: could not get executable path: %w", err)}
-			}
-			baseDir = filepath.Dir(goExecPath)
-		}
-
-		pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
-
-		if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
-			wd, _ := os.Getwd()
-			arch := runtime.GOARCH
-			osDir := runtime.GOOS + "_" + arch
-			altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
 
-			if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
-				pyExecPath = altPyExecPath
-			} else {
-				return userDetailsResultMsg{err: fmt.Errorf("python executable not found at %s or %s",
-					filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
-					filepath.Join("project_root", "dist_py", osDir, pyExecName))}
-			}
-		}
-
-		cmd := exec.Command(pyExecPath, username)
-		out, err := cmd.Output()
+// newUserProfileModel loads username's profile straight away.
+func newUserProfileModel(username string) (UserModel, tea.Cmd) {
+	m := NewUserModel()
+	m.input.SetValue(username)
+	m.input.Blur()
+	m.submitted = true
+	m.loading = true
+	return m, tea.Batch(m.spinner.Tick, callPythonGetUserDetails(username))
+}
 
+func callPythonGetUserDetails(username string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := runPyExec("user_details", username)
 		if err != nil {
-			var errData map[string]string
-			if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
-				return userDetailsResultMsg{err: fmt.Errorf(errData["error"])}
-			}
-			return userDetailsResultMsg{err: fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))}
-		}
-		var maybeErr map[string]string
-		if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
-			return userDetailsResultMsg{err: fmt.Errorf(maybeErr["error"])}
+			return userDetailsResultMsg{err: err}
 		}
 		var details UserDetails
 		if err := json.Unmarshal(out, &details); err != nil {
@@ -221,6 +203,21 @@ func (m UserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	if m.viewing && m.activeTab == userTabSocial && m.focusedList().searching {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			return m.updateSearch(msg)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -232,42 +229,85 @@ func (m UserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewing = false
 				m.submitted = false
 				m.userDetails = UserDetails{}
+				m.stats = userStats{}
+				m.statsLoaded = false
+				m.statsErr = nil
+				m.activeTab = userTabProfile
 				m.input.Focus()
 				return m, textinput.Blink
 			}
 			return NewMenuModel(), nil
 		case "enter":
+			if m.viewing && m.activeTab == userTabSocial {
+				if p, ok := m.focusedList().selected(); ok {
+					return newUserProfileModel(p.Username)
+				}
+				return m, nil
+			}
 			if !m.submitted {
+				if links.IsLink(m.input.Value()) {
+					return openLink(m.input.Value(), m)
+				}
 				m.submitted = true
 				m.loading = true
 				username := m.input.Value()
 				cmds = append(cmds, m.spinner.Tick, callPythonGetUserDetails(username))
 			}
-		case "tab":
+		case "e":
 			if m.viewing {
-				m.activeTab = (m.activeTab + 1) % len(m.tabs)
+				baseName := "exports/user_" + safeFileName(m.userDetails.Username)
+				return m, m.exporter.OpenDocument(userDocument(m.userDetails, m.following, m.followers), baseName)
 			}
-		case "shift+tab":
+		case "f":
 			if m.viewing {
-				m.activeTab--
-				if m.activeTab < 0 {
-					m.activeTab = len(m.tabs) - 1
-				}
+				next := NewFilmsModel(m.userDetails.Username, m)
+				return next, next.Init()
 			}
-		case "right", "l":
+		case "L":
 			if m.viewing {
-				if m.activeTab <= 2 { // Profile, Favorites, Recent
-					m.activeTab = (m.activeTab + 1) % len(m.tabs)
-				}
+				next := NewUserListsModel(m.userDetails.Username, false, m)
+				return next, next.Init()
+			}
+		case "a":
+			if m.viewing {
+				next := NewActivityModelFor(m.userDetails.Username, m)
+				return next, next.Init()
 			}
-		case "left", "h":
+		case "s":
+			if m.viewing {
+				next := NewSocialModel(m.userDetails.Username, m)
+				return next, next.Init()
+			}
+		case "tab":
 			if m.viewing {
-				if m.activeTab <= 2 { // Profile, Favorites, Recent
-					m.activeTab--
-					if m.activeTab < 0 {
-						m.activeTab = len(m.tabs) - 1
-					}
+				cmds = append(cmds, m.switchTab(1))
+			}
+		case "shift+tab":
+			if m.viewing {
+				cmds = append(cmds, m.switchTab(-1))
+			}
+		case "right", "l", "left", "h":
+			switch {
+			case !m.viewing:
+			case m.activeTab == userTabSocial:
+				m.socialFocus = 1 - m.socialFocus
+			case !m.pagedTab():
+				delta := 1
+				if msg.String() == "left" || msg.String() == "h" {
+					delta = -1
 				}
+				cmds = append(cmds, m.switchTab(delta))
+			}
+		case "up", "k", "down", "j", "pgup", "pgdown", "home", "end":
+			if m.viewing && m.activeTab == userTabSocial {
+				m.focusedList().move(msg.String())
+				return m, nil
+			}
+		case "/":
+			if m.viewing && m.activeTab == userTabSocial {
+				l := m.focusedList()
+				l.searching = true
+				return m, l.search.Focus()
 			}
 		}
 
@@ -285,18 +325,42 @@ func (m UserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.paginator.SetTotalPages(len(m.userDetails.Reviews))
 		m.paginator.Page = 0
-		maxSocialItems := max(len(m.userDetails.Following), len(m.userDetails.Followers))
-		m.socialPaginator.SetTotalPages(maxSocialItems)
-		m.socialPaginator.Page = 0
+		m.following = newSocialList("following", "Following", m.userDetails.Following)
+		m.followers = newSocialList("followers", "Followers", m.userDetails.Followers)
+		m.socialFocus = 0
+		m.socialLoaded = false
 
 		m.loading = false
 		m.viewing = true
 
+	case userStatsResultMsg:
+		if msg.username != m.userDetails.Username {
+			return m, nil
+		}
+		m.loadingStats = false
+		m.stats = msg.stats
+		m.statsErr = msg.err
+		return m, nil
+
+	case followsPageMsg:
+		if msg.run != m.socialRun || msg.username != m.userDetails.Username {
+			return m, nil
+		}
+		if msg.kind == m.following.kind {
+			return m, m.following.receive(msg.username, msg)
+		}
+		return m, m.followers.receive(msg.username, msg)
+
+	case exportResultMsg:
+		m.exportStatus = newExportStatus(msg)
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
+		m.exporter.input.Width = msg.Width - 20
 	}
 
-	if m.loading {
+	if m.loading || m.loadingStats {
 		m.spinner, cmd = m.spinner.Update(msg)
 		cmds = append(cmds, cmd)
 	} else if !m.viewing {
@@ -306,18 +370,75 @@ func (m UserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	if m.viewing {
 		switch m.activeTab {
-		case 3: // Reviews
+		case userTabReviews:
 			m.paginator, cmd = m.paginator.Update(msg)
 			cmds = append(cmds, cmd)
-		case 4: // Social
-			m.socialPaginator, cmd = m.socialPaginator.Update(msg)
-			cmds = append(cmds, cmd)
 		}
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// pagedTab reports whether the active tab uses ←/→ to change page rather
+// than tab.
+func (m UserModel) pagedTab() bool {
+	return m.activeTab == userTabReviews || m.activeTab == userTabSocial
+}
+
+// switchTab moves delta tabs along, fetching the stats and the social
+// lists the first time their tab is shown since they take a while to
+// gather.
+func (m *UserModel) switchTab(delta int) tea.Cmd {
+	m.activeTab = (m.activeTab + delta + len(m.tabs)) % len(m.tabs)
+	if m.activeTab == userTabStats && !m.statsLoaded {
+		m.statsLoaded = true
+		m.loadingStats = true
+		return tea.Batch(m.spinner.Tick, fetchUserStats(m.userDetails.Username))
+	}
+	if m.activeTab == userTabSocial && !m.socialLoaded {
+		m.socialLoaded = true
+		m.socialRun++
+		return tea.Batch(m.following.start(m.socialRun, m.userDetails.Username), m.followers.start(m.socialRun, m.userDetails.Username))
+	}
+	return nil
+}
+
+// focusedList is the Social tab list that keys act on.
+func (m *UserModel) focusedList() *socialList {
+	if m.socialFocus == 1 {
+		return &m.followers
+	}
+	return &m.following
+}
+
+// updateSearch sends a key to the focused list's search while it is
+// being typed.
+func (m UserModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	l := m.focusedList()
+	switch msg.String() {
+	case "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	case "esc":
+		l.searching = false
+		l.search.Blur()
+		l.search.SetValue("")
+		l.applySearch()
+		return m, nil
+	case "enter":
+		l.searching = false
+		l.search.Blur()
+		return m, nil
+	case "up", "down", "pgup", "pgdown":
+		l.move(msg.String())
+		return m, nil
+	}
+	l.search, cmd = l.search.Update(msg)
+	l.applySearch()
+	return m, cmd
+}
+
 func (m UserModel) View() string {
 	if m.quitting {
 		return "Goodbye!"
@@ -326,6 +447,10 @@ func (m UserModel) View() string {
 		return fmt.Sprintf("Error: %v", m.err)
 	}
 
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting profile: @%s", m.userDetails.Username))
+	}
+
 	if m.loading {
 		return fmt.Sprintf("\n\n   %s Fetching profile for '%s'...\n\n", m.spinner.View(), m.input.Value())
 	}
@@ -343,23 +468,30 @@ func (m UserModel) View() string {
 
 		var content string
 		switch m.activeTab {
-		case 0:
+		case userTabProfile:
 			content = m.renderProfileTab()
-		case 1:
+		case userTabStats:
+			content = m.renderStatsTab()
+		case userTabFavorites:
 			content = m.renderFavoritesTab()
-		case 2:
+		case userTabRecent:
 			content = m.renderRecentTab()
-		case 3:
+		case userTabReviews:
 			content = m.renderReviewsTab()
-		case 4:
+		case userTabSocial:
 			content = m.renderSocialTab()
 		}
 
 		helpText := "\n(Use Tab to switch tabs, ESC to go back)"
-		if m.activeTab <= 2 {
-			helpText = "\n(Use ←/→ or Tab to switch tabs, ESC to go back)"
+		if m.activeTab == userTabSocial {
+			helpText = "\n(Use ←/→ to switch lists, ↑/↓ to scroll, '/' to search, Enter to open a profile, Tab to switch tabs, 's' for follower analysis, 'e' to export, ESC to go back)"
+		} else if !m.pagedTab() {
+			helpText = "\n(Use ←/→ or Tab to switch tabs, 'f' for all films, 'L' for lists, 'a' for friends' activity, 's' for follower analysis, 'e' to export, ESC to go back)"
 		} else {
-			helpText = "\n(Use ←/→ to change page, Tab to switch tabs, ESC to go back)"
+			helpText = "\n(Use ←/→ to change page, Tab to switch tabs, 'f' for all films, 'L' for lists, 'a' for friends' activity, 's' for follower analysis, 'e' to export, ESC to go back)"
+		}
+		if exportMsg := m.exportStatus.View("Profile"); exportMsg != "" {
+			helpText += "\n" + exportMsg
 		}
 
 		return SearchBorderBox.Render(lipgloss.JoinVertical(lipgloss.Left, tabsRow, "", content)) + helpText
@@ -384,6 +516,57 @@ func (m UserModel) View() string {
 	return lipgloss.NewStyle().Margin(1, 2).Render(final)
 }
 
+// userDocument collects the profile, favorites, recent activity, reviews
+// and social tabs into a single exportable document. The social lists
+// are included as far as they have been loaded.
+func userDocument(u UserDetails, following, followers socialList) export.Document {
+	doc := export.Document{
+		Title: "@" + u.Username,
+		Data: struct {
+			UserDetails
+			FollowingList []Friend `json:"following_list"`
+			FollowersList []Friend `json:"followers_list"`
+		}{u, following.people, followers.people},
+	}
+
+	doc.Sections = append(doc.Sections, export.Section{
+		Heading: "Profile",
+		Fields: []export.Field{
+			{Name: "Films Watched", Value: fmt.Sprintf("%d", u.FilmsWatched)},
+			{Name: "This Year", Value: fmt.Sprintf("%d", u.This_year)},
+			{Name: "Last Watched", Value: u.LastWatched},
+			{Name: "Website", Value: u.Website},
+			{Name: "Location", Value: u.Location},
+		},
+		Text: u.Bio,
+	})
+	doc.Sections = append(doc.Sections, export.Section{Heading: "Favorites", Items: u.Favorites})
+	doc.Sections = append(doc.Sections, export.Section{Heading: "Recent", Items: u.Recent})
+
+	var reviews []string
+	for _, r := range u.Reviews {
+		reviews = append(reviews, fmt.Sprintf("**%s (%d)** ★ %.1f/5, %s\n%s", r.MovieName, r.MovieYear, r.Rating/2.0, r.ReviewDate, r.ReviewText))
+	}
+	doc.Sections = append(doc.Sections, export.Section{Heading: "Reviews", Items: reviews})
+	doc.Sections = append(doc.Sections, socialListSection(following), socialListSection(followers))
+
+	return doc
+}
+
+func socialListSection(l socialList) export.Section {
+	section := export.Section{Heading: fmt.Sprintf("%s (%d)", l.title, l.total)}
+	for _, p := range l.people {
+		section.Items = append(section.Items, fmt.Sprintf("%s (@%s)", p.Name, p.Username))
+	}
+	switch {
+	case len(l.people) == 0 && !l.done:
+		section.Text = "Not loaded; open the Social tab before exporting to include them."
+	case !l.done:
+		section.Text = fmt.Sprintf("Only the first %d were loaded.", len(l.people))
+	}
+	return section
+}
+
 func (m UserModel) renderProfileTab() string {
 	header := userHeaderStyle.Render(fmt.Sprintf("@%s", m.userDetails.Username))
 	bio := userBioStyle.Render(m.userDetails.Bio)
@@ -460,41 +643,13 @@ func (m UserModel) renderReviewsTab() string {
 }
 
 func (m UserModel) renderSocialTab() string {
-	maxItems := max(len(m.userDetails.Following), len(m.userDetails.Followers))
-	if maxItems == 0 {
-		return "No social information available."
-	}
-
-	start, end := m.socialPaginator.GetSliceBounds(maxItems)
-
-	var paginatedFollowing []string
-	if start < len(m.userDetails.Following) {
-		paginatedFollowing = m.userDetails.Following[start:min(end, len(m.userDetails.Following))]
-	}
-	var followingList []string
-	for _, f := range paginatedFollowing {
-		followingList = append(followingList, "• "+f)
+	barWidth := 20
+	columnWidth := m.width/2 - 5
+	if columnWidth < 30 {
+		columnWidth = 30
 	}
-	followingHeader := userSocialHeaderStyle.Render("Following")
-	followingBlock := lipgloss.JoinVertical(lipgloss.Left, followingHeader, userSocialListStyle.Render(strings.Join(followingList, "\n")))
-
-	var paginatedFollowers []string
-	if start < len(m.userDetails.Followers) {
-		paginatedFollowers = m.userDetails.Followers[start:min(end, len(m.userDetails.Followers))]
-	}
-	var followersList []string
-	for _, f := range paginatedFollowers {
-		followersList = append(followersList, "• "+f)
-	}
-	followersHeader := userSocialHeaderStyle.Render("Followers")
-	followersBlock := lipgloss.JoinVertical(lipgloss.Left, followersHeader, userSocialListStyle.Render(strings.Join(followersList, "\n")))
-
-	socialContent := lipgloss.JoinHorizontal(lipgloss.Top,
-		lipgloss.NewStyle().Width(m.width/2-5).Render(followingBlock),
-		lipgloss.NewStyle().Width(m.width/2-5).Render(followersBlock),
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(columnWidth).Render(m.following.View(m.socialFocus == 0, barWidth)),
+		lipgloss.NewStyle().Width(columnWidth).Render(m.followers.View(m.socialFocus == 1, barWidth)),
 	)
-
-	paginatorView := "\n\n" + m.socialPaginator.View()
-
-	return lipgloss.JoinVertical(lipgloss.Left, socialContent, paginatorView)
 }
@@ -0,0 +1,396 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	userStatsColumnStyle = lipgloss.NewStyle().
+				Width(26).
+				MarginRight(2)
+
+	userStatsNameStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("229"))
+
+	userStatsCountStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("242"))
+)
+
+// WatchedFilm is one film from a user's films page. The fields below
+// Detailed are only filled in for films whose page was fetched.
+type WatchedFilm struct {
+	Slug   string  `json:"slug"`
+	Title  string  `json:"title"`
+	Year   int     `json:"year"`
+	Rating float64 `json:"rating"`
+	Liked  bool    `json:"liked"`
+
+	Detailed  bool     `json:"detailed"`
+	Average   float64  `json:"average"`
+	Runtime   int      `json:"runtime"`
+	Genres    []string `json:"genres"`
+	Directors []string `json:"directors"`
+	Cast      []string `json:"cast"`
+	Countries []string `json:"countries"`
+}
+
+// Viewing is one diary entry.
+type Viewing struct {
+	Slug    string  `json:"slug"`
+	Date    string  `json:"date"`
+	Rating  float64 `json:"rating"`
+	Rewatch bool    `json:"rewatch"`
+	Runtime int     `json:"runtime"`
+}
+
+// userFilmData is everything the Stats tab is computed from.
+type userFilmData struct {
+	Username string        `json:"username"`
+	Films    []WatchedFilm `json:"films"`
+	Diary    []Viewing     `json:"diary"`
+	Total    int           `json:"total"`
+}
+
+type countedName struct {
+	Name  string
+	Count int
+}
+
+type yearCount struct {
+	Year  int
+	Count int
+}
+
+// periodStats summarises the films logged in some period.
+type periodStats struct {
+	Films         int
+	Minutes       int
+	AverageRating float64
+	TopGenre      string
+}
+
+// userStats is the year-in-review shown on a profile's Stats tab.
+type userStats struct {
+	Films    int
+	Detailed int
+	Minutes  int
+
+	// AverageRating is the mean of all the user's ratings. SiteAverage is
+	// the Letterboxd average of the rated films whose page was fetched,
+	// and ComparedAverage the user's average over those same films.
+	AverageRating   float64
+	Rated           int
+	SiteAverage     float64
+	ComparedAverage float64
+
+	PerYear      []yearCount
+	TopGenres    []countedName
+	TopDirectors []countedName
+	TopActors    []countedName
+	TopCountries []countedName
+
+	ThisYear     periodStats
+	CurrentYear  int
+	DiaryEntries int
+}
+
+type userStatsResultMsg struct {
+	username string
+	stats    userStats
+	err      error
+}
+
+func callPythonGetUserStats(username string) (userFilmData, error) {
+	out, err := runPyExec("get_user_stats", username)
+	if err != nil {
+		return userFilmData{}, err
+	}
+	var data userFilmData
+	if err := json.Unmarshal(out, &data); err != nil {
+		return userFilmData{}, fmt.Errorf("failed to parse user stats JSON: %w", err)
+	}
+	return data, nil
+}
+
+func fetchUserStats(username string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := callPythonGetUserStats(username)
+		if err != nil {
+			return userStatsResultMsg{username: username, err: err}
+		}
+		return userStatsResultMsg{username: username, stats: computeUserStats(data, time.Now())}
+	}
+}
+
+// computeUserStats derives the year-in-review from a user's films and
+// diary. Films per year and "this year" count diary entries; hours count
+// every logged viewing plus one viewing of each film never logged.
+func computeUserStats(data userFilmData, now time.Time) userStats {
+	s := userStats{
+		Films:        data.Total,
+		CurrentYear:  now.Year(),
+		DiaryEntries: len(data.Diary),
+	}
+	if s.Films < len(data.Films) {
+		s.Films = len(data.Films)
+	}
+
+	films := make(map[string]WatchedFilm, len(data.Films))
+	genres := map[string]int{}
+	directors := map[string]int{}
+	actors := map[string]int{}
+	countries := map[string]int{}
+
+	var ratingSum, siteSum, comparedSum float64
+	compared := 0
+	for _, f := range data.Films {
+		films[f.Slug] = f
+		if f.Rating > 0 {
+			ratingSum += f.Rating
+			s.Rated++
+			if f.Average > 0 {
+				siteSum += f.Average
+				comparedSum += f.Rating
+				compared++
+			}
+		}
+		if !f.Detailed {
+			continue
+		}
+		s.Detailed++
+		countEach(genres, f.Genres)
+		countEach(directors, f.Directors)
+		countEach(actors, f.Cast)
+		countEach(countries, f.Countries)
+	}
+	if s.Rated > 0 {
+		s.AverageRating = ratingSum / float64(s.Rated)
+	}
+	if compared > 0 {
+		s.SiteAverage = siteSum / float64(compared)
+		s.ComparedAverage = comparedSum / float64(compared)
+	}
+
+	perYear := map[int]int{}
+	logged := map[string]bool{}
+	var thisYear []Viewing
+	for _, v := range data.Diary {
+		logged[v.Slug] = true
+		s.Minutes += viewingRuntime(v, films)
+
+		year, err := strconv.Atoi(strings.SplitN(v.Date, "-", 2)[0])
+		if err != nil {
+			continue
+		}
+		perYear[year]++
+		if year == s.CurrentYear {
+			thisYear = append(thisYear, v)
+		}
+	}
+	for _, f := range data.Films {
+		if !logged[f.Slug] {
+			s.Minutes += f.Runtime
+		}
+	}
+
+	for year, count := range perYear {
+		s.PerYear = append(s.PerYear, yearCount{Year: year, Count: count})
+	}
+	sort.Slice(s.PerYear, func(i, j int) bool { return s.PerYear[i].Year < s.PerYear[j].Year })
+
+	s.TopGenres = topNames(genres, 5)
+	s.TopDirectors = topNames(directors, 5)
+	s.TopActors = topNames(actors, 5)
+	s.TopCountries = topNames(countries, 5)
+	s.ThisYear = summarisePeriod(thisYear, films)
+	return s
+}
+
+func summarisePeriod(viewings []Viewing, films map[string]WatchedFilm) periodStats {
+	p := periodStats{Films: len(viewings)}
+	genres := map[string]int{}
+	var ratingSum float64
+	rated := 0
+	for _, v := range viewings {
+		p.Minutes += viewingRuntime(v, films)
+		if v.Rating > 0 {
+			ratingSum += v.Rating
+			rated++
+		}
+		countEach(genres, films[v.Slug].Genres)
+	}
+	if rated > 0 {
+		p.AverageRating = ratingSum / float64(rated)
+	}
+	if top := topNames(genres, 1); len(top) > 0 {
+		p.TopGenre = top[0].Name
+	}
+	return p
+}
+
+func viewingRuntime(v Viewing, films map[string]WatchedFilm) int {
+	if v.Runtime > 0 {
+		return v.Runtime
+	}
+	return films[v.Slug].Runtime
+}
+
+func countEach(counts map[string]int, names []string) {
+	for _, n := range names {
+		if n != "" {
+			counts[n]++
+		}
+	}
+}
+
+// topNames returns the n most frequent names, ties broken alphabetically.
+func topNames(counts map[string]int, n int) []countedName {
+	out := make([]countedName, 0, len(counts))
+	for name, count := range counts {
+		out = append(out, countedName{Name: name, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+func formatHours(minutes int) string {
+	return fmt.Sprintf("%s hrs", formatLargeNumber(minutes/60))
+}
+
+// ratingComparison describes how a user's average rating compares with
+// Letterboxd's average for the same films.
+func ratingComparison(user, site float64) string {
+	diff := user - site
+	switch {
+	case diff >= 0.05:
+		return fmt.Sprintf("%.2f more generous than average", diff)
+	case diff <= -0.05:
+		return fmt.Sprintf("%.2f harsher than average", -diff)
+	default:
+		return "in line with the average"
+	}
+}
+
+func (m UserModel) renderStatsTab() string {
+	if m.loadingStats {
+		return fmt.Sprintf("%s Crunching @%s's films and diary...", m.spinner.View(), m.userDetails.Username)
+	}
+	if m.statsErr != nil {
+		return fmt.Sprintf("Could not load stats: %v", m.statsErr)
+	}
+	s := m.stats
+
+	row := func(label, value string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Left, userLabelStyle.Render(label), userStatValueStyle.Render(value))
+	}
+
+	summary := []string{
+		row("Films:", formatLargeNumber(s.Films)),
+		row("Hours Watched:", formatHours(s.Minutes)),
+	}
+	if s.Rated > 0 {
+		line := fmt.Sprintf("★ %.2f", s.AverageRating)
+		if s.SiteAverage > 0 {
+			line += movieSubtitleStyle.Render(fmt.Sprintf("  (Letterboxd ★ %.2f for the same films, you are %s)", s.SiteAverage, ratingComparison(s.ComparedAverage, s.SiteAverage)))
+		}
+		summary = append(summary, row("Average Rating:", line))
+	}
+
+	thisYear := []string{userSocialHeaderStyle.Render(fmt.Sprintf("%d so far", s.CurrentYear))}
+	if s.ThisYear.Films == 0 {
+		thisYear = append(thisYear, "Nothing logged yet this year.")
+	} else {
+		thisYear = append(thisYear,
+			row("Films Logged:", fmt.Sprintf("%d", s.ThisYear.Films)),
+			row("Hours:", formatHours(s.ThisYear.Minutes)),
+		)
+		if s.ThisYear.AverageRating > 0 {
+			thisYear = append(thisYear, row("Average Rating:", fmt.Sprintf("★ %.2f", s.ThisYear.AverageRating)))
+		}
+		if s.ThisYear.TopGenre != "" {
+			thisYear = append(thisYear, row("Top Genre:", s.ThisYear.TopGenre))
+		}
+	}
+
+	blocks := []string{
+		lipgloss.JoinVertical(lipgloss.Left, summary...),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, thisYear...),
+		"",
+		userSocialHeaderStyle.Render("Films per year"),
+		m.renderFilmsPerYear(),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			renderTopNames("Genres", s.TopGenres),
+			renderTopNames("Directors", s.TopDirectors),
+		),
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			renderTopNames("Actors", s.TopActors),
+			renderTopNames("Countries", s.TopCountries),
+		),
+	}
+	if s.Detailed < s.Films {
+		blocks = append(blocks, movieSubtitleStyle.Render(
+			fmt.Sprintf("Genres, people, countries and hours are based on the %d most recently logged of %d films.", s.Detailed, s.Films)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, blocks...)
+}
+
+func (m UserModel) renderFilmsPerYear() string {
+	years := m.stats.PerYear
+	if len(years) == 0 {
+		return "No diary entries to chart."
+	}
+
+	most := 0
+	for _, y := range years {
+		if y.Count > most {
+			most = y.Count
+		}
+	}
+	width := 40
+	if m.width > 0 && m.width < 80 {
+		width = 20
+	}
+
+	var lines []string
+	for _, y := range years {
+		length := y.Count * width / most
+		if length == 0 {
+			length = 1
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			histogramLabelStyle.Render(strconv.Itoa(y.Year)),
+			histogramBarStyle.Render(strings.Repeat("█", length)),
+			histogramCountStyle.Render(strconv.Itoa(y.Count))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderTopNames(title string, names []countedName) string {
+	lines := []string{userSocialHeaderStyle.Render(title)}
+	if len(names) == 0 {
+		lines = append(lines, userStatsCountStyle.Render("none yet"))
+	}
+	for i, n := range names {
+		lines = append(lines, fmt.Sprintf("%d. %s %s", i+1,
+			userStatsNameStyle.Render(n.Name), userStatsCountStyle.Render(fmt.Sprintf("(%d)", n.Count))))
+	}
+	return userStatsColumnStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -1,21 +1,20 @@
 package ui
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"runtime"
+	"math/rand"
+	"sort"
 	"strings"
-	"time"
 
+	"github.com/anshonweb/letterbox-cli/internal/export"
+	"github.com/anshonweb/letterbox-cli/internal/links"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 )
 
 var (
@@ -41,168 +40,437 @@ var (
 				MarginTop(1)
 )
 
-type watchlistResultMsg struct {
-	movies []Movie
-	err    error
+// WatchlistFilm is a film on a watchlist. Director, Runtime, Rating and
+// Genres are only known once the film has been enriched.
+type WatchlistFilm struct {
+	Movie
+	Runtime int      `json:"runtime,omitempty"`
+	Rating  float64  `json:"rating,omitempty"`
+	Genres  []string `json:"genres,omitempty"`
+}
+
+// watchlistFilter selects and orders a watchlist. Empty fields mean no
+// filter. It is applied to the fetched watchlist, so changing it never
+// fetches the watchlist again.
+type watchlistFilter struct {
+	Sort    string
+	Decade  string
+	Genre   string
+	Runtime string
+}
+
+var (
+	watchlistSorts      = []string{"added", "title", "year", "runtime", "rating"}
+	watchlistSortLabels = map[string]string{
+		"added":   "when added",
+		"title":   "title",
+		"year":    "release date",
+		"runtime": "shortest first",
+		"rating":  "average rating",
+	}
+	watchlistRuntimes      = []string{"", "under-90", "90-120", "120-150", "over-150"}
+	watchlistRuntimeLabels = map[string]string{
+		"under-90": "under 90 min",
+		"90-120":   "90–120 min",
+		"120-150":  "120–150 min",
+		"over-150": "over 150 min",
+	}
+)
+
+// needsSummaries reports whether f sorts or filters on details that only
+// enrichment provides.
+func (f watchlistFilter) needsSummaries() bool {
+	return f.Genre != "" || f.Runtime != "" || f.Sort == "runtime" || f.Sort == "rating"
 }
 
-type exportResultMsg struct {
-	filePath string
-	err      error
+// keep reports whether film passes f. Films whose details are not known
+// yet fail filters that need them.
+func (f watchlistFilter) keep(film WatchlistFilm) bool {
+	if f.Decade != "" && (film.Year == 0 || fmt.Sprintf("%ds", film.Year/10*10) != f.Decade) {
+		return false
+	}
+	if f.Genre != "" && !hasGenre(film.Genres, f.Genre) {
+		return false
+	}
+	return inRuntimeBand(f.Runtime, film.Runtime)
 }
 
+// hasGenre matches genre names such as "Science Fiction" against the
+// slugs in filmsGenres.
+func hasGenre(genres []string, slug string) bool {
+	for _, g := range genres {
+		if strings.EqualFold(strings.ReplaceAll(g, " ", "-"), slug) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the films that pass f in f's order. films must be in the
+// order they were added, which is the "added" order.
+func (f watchlistFilter) apply(films []WatchlistFilm) []WatchlistFilm {
+	var kept []WatchlistFilm
+	for _, film := range films {
+		if f.keep(film) {
+			kept = append(kept, film)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		a, b := kept[i], kept[j]
+		switch f.Sort {
+		case "title":
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		case "year":
+			return a.Year > b.Year
+		case "runtime":
+			return knownBefore(float64(a.Runtime), float64(b.Runtime), true)
+		case "rating":
+			return knownBefore(a.Rating, b.Rating, false)
+		}
+		return false
+	})
+	return kept
+}
+
+// knownBefore orders two values where zero means unknown: known values
+// first, ascending or descending, then the unknown ones.
+func knownBefore(a, b float64, ascending bool) bool {
+	if a == 0 || b == 0 {
+		return a != 0 && b == 0
+	}
+	if ascending {
+		return a < b
+	}
+	return a > b
+}
+
+// inRuntimeBand reports whether a runtime in minutes falls in one of
+// watchlistRuntimes. Unknown runtimes are in no band.
+func inRuntimeBand(band string, minutes int) bool {
+	switch band {
+	case "":
+		return true
+	case "under-90":
+		return minutes > 0 && minutes < 90
+	case "90-120":
+		return minutes >= 90 && minutes < 120
+	case "120-150":
+		return minutes >= 120 && minutes < 150
+	case "over-150":
+		return minutes >= 150
+	}
+	return false
+}
+
+func (f watchlistFilter) String() string {
+	parts := []string{"sorted by " + watchlistSortLabels[f.Sort]}
+	if f.Decade != "" {
+		parts = append(parts, f.Decade)
+	}
+	if f.Genre != "" {
+		parts = append(parts, roleLabel(f.Genre))
+	}
+	if f.Runtime != "" {
+		parts = append(parts, watchlistRuntimeLabels[f.Runtime])
+	}
+	return strings.Join(parts, " · ")
+}
+
+type watchlistResultMsg struct {
+	username    string
+	films       []WatchlistFilm
+	changes     *listChanges
+	snapshotErr error
+	err         error
+}
+
+// watchlistTitles lets fuzzy match against film titles.
+type watchlistTitles []WatchlistFilm
+
+func (t watchlistTitles) String(i int) string { return t[i].Title }
+func (t watchlistTitles) Len() int            { return len(t) }
+
 type WatchlistModel struct {
-	input               textinput.Model
-	spinner             spinner.Model
-	table               table.Model
-	showSpinner         bool
-	showTable           bool
-	submitted           bool
-	quitting            bool
-	err                 error
-	promptingExportPath bool
-	exportInput         textinput.Model
-	exportPath          string
-	exportErr           error
-	lastExportMsg       time.Time
-	watchlist           []Movie
-	targetUser          string
-	baseStyle           lipgloss.Style
+	input        textinput.Model
+	searchInput  textinput.Model
+	spinner      spinner.Model
+	table        table.Model
+	showSpinner  bool
+	showTable    bool
+	submitted    bool
+	searching    bool
+	quitting     bool
+	err          error
+	exporter     exportPrompt
+	exportStatus exportStatus
+	filter       watchlistFilter
+	watchlist    []WatchlistFilm // as fetched, most recently added first
+	filtered     []WatchlistFilm // watchlist after filter
+	visible      []WatchlistFilm // filtered after the fuzzy search
+	targetUser   string
+	changes      *listChanges
+	snapshotErr  error
+	enrich       enrichment
+	enrichRuns   int
+	enriched     bool
+	summaries    map[string]filmSummary
+	width        int
+	baseStyle    lipgloss.Style
 }
 
 func NewWatchlistModel() WatchlistModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter Letterboxd username..."
 	ti.Focus()
-	ti.CharLimit = 32
+	ti.CharLimit = 128
 	ti.Width = 40
 	ti.Prompt = "Username: "
 	ti.PromptStyle = watchInputPromptStyle
 	ti.Cursor.Style = watchInputCursorStyle
 	ti.TextStyle = watchInputTextStyle
 
+	si := textinput.New()
+	si.Placeholder = "fuzzy title search..."
+	si.CharLimit = 64
+	si.Width = 40
+	si.Prompt = "/ "
+	si.PromptStyle = watchInputPromptStyle
+	si.Cursor.Style = watchInputCursorStyle
+	si.TextStyle = watchInputTextStyle
+
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00A86B"))
 
-	exportTi := textinput.New()
-	exportTi.Placeholder = "e.g., my_watchlist.csv or exports/watchlist.csv"
-	exportTi.CharLimit = 256
-	exportTi.Width = 60
-	exportTi.Prompt = "Export Path (relative): "
-	exportTi.PromptStyle = watchInputPromptStyle.Copy()
-	exportTi.Cursor.Style = watchInputCursorStyle.Copy()
-	exportTi.TextStyle = watchInputTextStyle.Copy()
+	columns := []table.Column{
+		{Title: "Title", Width: 40},
+		{Title: "Year", Width: 6},
+		{Title: "Director", Width: 25},
+	}
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	exporter := newExportPrompt("e.g., my_watchlist.csv or exports/watchlist.csv",
+		watchInputPromptStyle, watchInputCursorStyle, watchInputTextStyle)
 
 	return WatchlistModel{
 		input:       ti,
+		searchInput: si,
 		spinner:     sp,
-		exportInput: exportTi,
+		table:       t,
+		filter:      watchlistFilter{Sort: watchlistSorts[0]},
+		summaries:   map[string]filmSummary{},
+		exporter:    exporter,
 		baseStyle:   lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
 	}
 }
 
-func callPythonGetWatchlist(username string) tea.Cmd {
-	return func() tea.Msg {
-		pyExecName := "get_watchlist"
-		if runtime.GOOS == "windows" {
-			pyExecName += ".exe"
-		}
+// newUserWatchlistModel loads username's watchlist straight away.
+func newUserWatchlistModel(username string) (WatchlistModel, tea.Cmd) {
+	m := NewWatchlistModel()
+	m.input.SetValue(username)
+	m.input.Blur()
+	m.submitted = true
+	m.showSpinner = true
+	m.targetUser = username
+	return m, tea.Batch(m.spinner.Tick, fetchWatchlist(username))
+}
 
-		baseDir := ""
-		snapDir := os.Getenv("SNAP")
-		if snapDir != "" {
-			baseDir = snapDir
-		} else {
-			goExecPath, err := os.Executable()
-			if err != nil {
-				return watchlistResultMsg{err: fmt.Errorf("fatal: could not get executable path: %w", err)}
-			}
-			baseDir = filepath.Dir(goExecPath)
+// callPythonGetWatchlist fetches every film on username's watchlist, most
+// recently added first.
+func callPythonGetWatchlist(username string) ([]WatchlistFilm, error) {
+	out, err := runPyExec("get_watchlist", username)
+	if err != nil {
+		return nil, err
+	}
+	var films []WatchlistFilm
+	if err := json.Unmarshal(out, &films); err != nil {
+		return nil, fmt.Errorf("failed to parse watchlist JSON: %w", err)
+	}
+	return films, nil
+}
+
+func fetchWatchlist(username string) tea.Cmd {
+	return func() tea.Msg {
+		films, err := callPythonGetWatchlist(username)
+		msg := watchlistResultMsg{username: username, films: films, err: err}
+		if err == nil {
+			msg.changes, msg.snapshotErr = saveWatchlistSnapshot(username, films)
 		}
+		return msg
+	}
+}
 
-		pyExecPath := filepath.Join(baseDir, "py_execs", pyExecName)
+// watchlistColumns reports which optional columns films have data for.
+func watchlistColumns(films []WatchlistFilm) (runtimes, ratings, genres bool) {
+	for _, f := range films {
+		runtimes = runtimes || f.Runtime > 0
+		ratings = ratings || f.Rating > 0
+		genres = genres || len(f.Genres) > 0
+	}
+	return runtimes, ratings, genres
+}
 
-		if _, err := os.Stat(pyExecPath); os.IsNotExist(err) {
-			wd, _ := os.Getwd()
-			arch := runtime.GOARCH
-			osDir := runtime.GOOS + "_" + arch
-			altPyExecPath := filepath.Join(wd, "..", "..", "dist_py", osDir, pyExecName)
+// optionalNumber blanks out values that were never fetched rather than
+// showing them as zero.
+func optionalNumber(known bool, value string) string {
+	if !known {
+		return ""
+	}
+	return value
+}
 
-			if _, altErr := os.Stat(altPyExecPath); !os.IsNotExist(altErr) {
-				pyExecPath = altPyExecPath
-			} else {
-				return watchlistResultMsg{err: fmt.Errorf("python executable not found at %s or %s",
-					filepath.Join("$SNAP or ExecDir", "py_execs", pyExecName),
-					filepath.Join("project_root", "dist_py", osDir, pyExecName))}
-			}
+// applySummaries copies fetched details onto the films they belong to.
+func applySummaries(films []WatchlistFilm, summaries map[string]filmSummary) {
+	for i := range films {
+		s, ok := summaries[films[i].Slug]
+		if !ok {
+			continue
 		}
+		films[i].Director = s.Director
+		if s.Runtime > 0 {
+			films[i].Runtime = s.Runtime
+		}
+		films[i].Rating = s.Rating
+		films[i].Genres = s.Genres
+	}
+}
 
-		cmd := exec.Command(pyExecPath, username)
-		out, err := cmd.Output()
-
-		if err != nil {
-			var errData map[string]string
-			if json.Unmarshal(out, &errData) == nil && errData["error"] != "" {
-				return watchlistResultMsg{err: fmt.Errorf(errData["error"])}
-			}
-			return watchlistResultMsg{err: fmt.Errorf("failed to run script '%s': %w, output: %s", pyExecPath, err, string(out))}
+func watchlistTable(watchlist []WatchlistFilm, username string) export.Table {
+	t := export.Table{
+		Title: fmt.Sprintf("%s's Watchlist", username),
+		Columns: []export.Column{
+			{Name: "Title"},
+			{Name: "Year", Numeric: true},
+			{Name: "Director"},
+		},
+	}
+	runtimes, ratings, genres := watchlistColumns(watchlist)
+	if runtimes {
+		t.Columns = append(t.Columns, export.Column{Name: "Runtime", Numeric: true})
+	}
+	if ratings {
+		t.Columns = append(t.Columns, export.Column{Name: "Rating", Numeric: true})
+	}
+	if genres {
+		t.Columns = append(t.Columns, export.Column{Name: "Genres"})
+	}
+	for _, movie := range watchlist {
+		row := []string{movie.Title, fmt.Sprintf("%d", movie.Year), movie.Director}
+		if runtimes {
+			row = append(row, optionalNumber(movie.Runtime > 0, fmt.Sprintf("%d", movie.Runtime)))
 		}
-		var maybeErr map[string]string
-		if json.Unmarshal(out, &maybeErr) == nil && maybeErr["error"] != "" {
-			return watchlistResultMsg{err: fmt.Errorf(maybeErr["error"])}
+		if ratings {
+			row = append(row, optionalNumber(movie.Rating > 0, fmt.Sprintf("%.2f", movie.Rating)))
 		}
-		var movies []Movie
-		if err := json.Unmarshal(out, &movies); err != nil {
-			return watchlistResultMsg{err: fmt.Errorf("failed to parse watchlist JSON: %w", err)}
+		if genres {
+			row = append(row, strings.Join(movie.Genres, ", "))
 		}
-		return watchlistResultMsg{movies: movies}
+		t.Rows = append(t.Rows, row)
 	}
+	return t
 }
 
-func exportWatchlistToCSV(watchlist []Movie, username, relativeFilePath string) tea.Cmd {
-	return func() tea.Msg {
-		filePath, err := filepath.Abs(relativeFilePath)
-		if err != nil {
-			return exportResultMsg{err: fmt.Errorf("invalid path format: %w", err)}
-		}
+// refilter applies the filter again after it changed, fetching the
+// details it needs for any film that lacks them.
+func (m *WatchlistModel) refilter() tea.Cmd {
+	m.table.SetCursor(0)
+	m.applySearch()
+	if m.filter.needsSummaries() && !m.enrich.running {
+		return m.startEnrichment()
+	}
+	return nil
+}
 
-		dir := filepath.Dir(filePath)
-		if dir != "." && dir != "/" {
-			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-				if os.IsPermission(err) {
-					return exportResultMsg{err: fmt.Errorf("permission denied creating directory %s", dir)}
-				}
-				return exportResultMsg{err: fmt.Errorf("failed to create directory %s: %w", dir, err)}
-			}
+// startEnrichment fetches details for every film on the watchlist that
+// does not have them yet, replacing any pass already running.
+func (m *WatchlistModel) startEnrichment() tea.Cmd {
+	m.enrich.stop()
+	var slugs []string
+	for _, f := range m.watchlist {
+		if _, ok := m.summaries[f.Slug]; !ok && f.Slug != "" {
+			slugs = append(slugs, f.Slug)
 		}
+	}
+	m.enriched = true
+	if len(slugs) == 0 {
+		m.enrich = enrichment{}
+		return nil
+	}
+	m.enrichRuns++
+	var cmd tea.Cmd
+	m.enrich, cmd = startEnrichment(m.enrichRuns, slugs, false)
+	return cmd
+}
 
-		file, err := os.Create(filePath)
-		if err != nil {
-			if os.IsPermission(err) {
-				return exportResultMsg{err: fmt.Errorf("permission denied creating file %s", filePath)}
-			}
-			return exportResultMsg{err: fmt.Errorf("failed to create file %s: %w", filePath, err)}
+// applySearch filters and sorts the watchlist, then narrows it to the
+// films matching the fuzzy search, best matches first.
+func (m *WatchlistModel) applySearch() {
+	m.filtered = m.filter.apply(m.watchlist)
+	query := strings.TrimSpace(m.searchInput.Value())
+	if query == "" {
+		m.visible = m.filtered
+	} else {
+		matches := fuzzy.FindFrom(query, watchlistTitles(m.filtered))
+		m.visible = make([]WatchlistFilm, len(matches))
+		for i, match := range matches {
+			m.visible[i] = m.filtered[match.Index]
 		}
-		defer file.Close()
+	}
+	m.updateRows()
+}
 
-		writer := csv.NewWriter(file)
-		defer writer.Flush()
+func (m *WatchlistModel) updateRows() {
+	columns := []table.Column{
+		{Title: "Title", Width: 40},
+		{Title: "Year", Width: 6},
+		{Title: "Director", Width: 25},
+	}
+	runtimes, ratings, genres := watchlistColumns(m.visible)
+	if runtimes {
+		columns = append(columns, table.Column{Title: "Runtime", Width: 8})
+	}
+	if ratings {
+		columns = append(columns, table.Column{Title: "Rating", Width: 6})
+	}
+	if genres {
+		columns = append(columns, table.Column{Title: "Genres", Width: 30})
+	}
 
-		header := []string{"Title", "Year", "Director"}
-		if err := writer.Write(header); err != nil {
-			return exportResultMsg{err: fmt.Errorf("failed to write CSV header: %w", err)}
+	rows := make([]table.Row, len(m.visible))
+	for i, movie := range m.visible {
+		rows[i] = table.Row{movie.Title, fmt.Sprintf("%d", movie.Year), movie.Director}
+		if runtimes {
+			rows[i] = append(rows[i], optionalNumber(movie.Runtime > 0, fmt.Sprintf("%d min", movie.Runtime)))
 		}
-
-		for _, movie := range watchlist {
-			row := []string{movie.Title, fmt.Sprintf("%d", movie.Year), movie.Director}
-			if err := writer.Write(row); err != nil {
-				fmt.Printf("Error writing row for %s: %v\n", movie.Title, err)
-			}
+		if ratings {
+			rows[i] = append(rows[i], optionalNumber(movie.Rating > 0, fmt.Sprintf("%.2f", movie.Rating)))
 		}
+		if genres {
+			rows[i] = append(rows[i], strings.Join(movie.Genres, ", "))
+		}
+	}
+	m.table.SetRows(nil)
+	m.table.SetColumns(columns)
+	m.table.SetRows(rows)
+	if m.table.Cursor() >= len(rows) {
+		m.table.SetCursor(0)
+	}
+}
 
-		return exportResultMsg{filePath: filePath}
+// progressWidth is the length of the enrichment progress bar.
+func (m WatchlistModel) progressWidth() int {
+	if m.width > 0 && m.width < 100 {
+		return 20
 	}
+	return 40
 }
 
 func (m WatchlistModel) Init() tea.Cmd {
@@ -213,33 +481,39 @@ func (m WatchlistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
-	if m.promptingExportPath {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
+	if m.exporter.active {
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		m.exporter, cmd = m.exporter.Update(msg)
+		return m, cmd
+	}
+
+	if m.searching {
+		if msg, ok := msg.(tea.KeyMsg); ok {
 			switch msg.String() {
-			case "ctrl+c", "q":
+			case "ctrl+c":
 				m.quitting = true
 				return m, tea.Quit
 			case "esc":
-				m.promptingExportPath = false
-				m.exportInput.Blur()
-				m.exportInput.Reset()
-				m.exportErr = nil
+				m.searching = false
+				m.searchInput.Blur()
+				m.searchInput.SetValue("")
+				m.applySearch()
 				return m, nil
 			case "enter":
-				m.promptingExportPath = false
-				m.exportInput.Blur()
-				path := m.exportInput.Value()
-				m.exportInput.Reset()
-				m.exportPath = ""
-				m.exportErr = nil
-				cmds = append(cmds, exportWatchlistToCSV(m.watchlist, m.targetUser, path))
-				return m, tea.Batch(cmds...)
+				m.searching = false
+				m.searchInput.Blur()
+				return m, nil
+			case "up", "down", "pgup", "pgdown":
+				m.table, cmd = m.table.Update(msg)
+				return m, cmd
 			}
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			m.applySearch()
+			return m, cmd
 		}
-		m.exportInput, cmd = m.exportInput.Update(msg)
-		cmds = append(cmds, cmd)
-		return m, tea.Batch(cmds...)
 	}
 
 	switch msg := msg.(type) {
@@ -257,12 +531,21 @@ func (m WatchlistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.input.Focus()
 				return m, nil
 			}
+			if m.showTable && m.searchInput.Value() != "" {
+				m.searchInput.SetValue("")
+				m.applySearch()
+				return m, nil
+			}
 			if m.showTable {
 				m.showTable = false
 				m.submitted = false
 				m.input.Focus()
-				m.exportPath = ""
-				m.exportErr = nil
+				m.exportStatus = exportStatus{}
+				m.filter = watchlistFilter{Sort: watchlistSorts[0]}
+				m.enrich.stop()
+				m.enrich = enrichment{}
+				m.enriched = false
+				m.changes, m.snapshotErr = nil, nil
 				return m, nil
 			} else {
 				return NewMenuModel(), nil
@@ -270,71 +553,125 @@ func (m WatchlistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			if !m.submitted {
+				if links.IsLink(m.input.Value()) {
+					return openLink(m.input.Value(), m)
+				}
 				m.submitted = true
 				m.showSpinner = true
 				m.targetUser = m.input.Value()
-				cmds = append(cmds, m.spinner.Tick, callPythonGetWatchlist(m.targetUser))
+				m.summaries = map[string]filmSummary{}
+				cmds = append(cmds, m.spinner.Tick, fetchWatchlist(m.targetUser))
+			} else if m.showTable && !m.showSpinner {
+				cursor := m.table.Cursor()
+				if cursor < len(m.visible) {
+					m.enrich.stop()
+					return newFilmDetailsModel(m.visible[cursor].Movie, m)
+				}
+			}
+
+		case "/":
+			if m.showTable && !m.showSpinner {
+				m.searching = true
+				return m, m.searchInput.Focus()
+			}
+
+		case "s", "d", "g", "t":
+			if m.showTable && !m.showSpinner {
+				switch msg.String() {
+				case "s":
+					m.filter.Sort = nextOption(watchlistSorts, m.filter.Sort)
+				case "d":
+					m.filter.Decade = nextOption(filmsDecades, m.filter.Decade)
+				case "g":
+					m.filter.Genre = nextOption(filmsGenres, m.filter.Genre)
+				case "t":
+					m.filter.Runtime = nextOption(watchlistRuntimes, m.filter.Runtime)
+				}
+				return m, m.refilter()
+			}
+
+		case "i":
+			if m.showTable && !m.showSpinner && !m.enrich.running {
+				return m, m.startEnrichment()
+			}
+
+		case "v":
+			if m.showTable && !m.showSpinner && len(m.visible) > 0 {
+				m.enrich.stop()
+				movies := make([]Movie, len(m.visible))
+				for i, f := range m.visible {
+					movies[i] = f.Movie
+				}
+				return startPoll(fmt.Sprintf("What should we watch from @%s's watchlist?", m.targetUser), movies, m)
+			}
+
+		case "c":
+			if m.showTable && !m.showSpinner {
+				m.enrich.stop()
+				next := NewWatchlistChangesModel(m.targetUser, m)
+				return next, next.Init()
+			}
+
+		case "p":
+			// Pick a random film from what is currently shown.
+			if m.showTable && !m.showSpinner && len(m.visible) > 0 {
+				film := m.visible[rand.Intn(len(m.visible))]
+				m.enrich.stop()
+				return newFilmDetailsModel(film.Movie, m)
 			}
 
 		case "e":
-			if m.showTable && len(m.watchlist) > 0 {
-				m.promptingExportPath = true
-				m.exportInput.Focus()
-				safeUsername := strings.ReplaceAll(strings.ReplaceAll(m.targetUser, "/", "_"), " ", "_")
-				defaultFileName := fmt.Sprintf("exports/watchlist_%s.csv", safeUsername)
-				m.exportInput.SetValue(defaultFileName)
-				return m, textinput.Blink
+			if m.showTable && len(m.visible) > 0 {
+				return m, m.exporter.Open(watchlistTable(m.visible, m.targetUser), "exports/watchlist_"+safeFileName(m.targetUser))
 			}
 		}
 
 	case watchlistResultMsg:
+		// Drop results for a user that has since changed.
+		if msg.username != m.targetUser {
+			return m, nil
+		}
 		m.showSpinner = false
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
 			m.showTable = true
-			m.watchlist = msg.movies
-
-			rows := []table.Row{}
-			for _, movie := range m.watchlist {
-				rows = append(rows, table.Row{
-					movie.Title,
-					fmt.Sprintf("%d", movie.Year),
-					movie.Director,
-				})
-			}
-
-			columns := []table.Column{
-				{Title: "Title", Width: 40},
-				{Title: "Year", Width: 6},
-				{Title: "Director", Width: 25},
+			m.watchlist = msg.films
+			m.changes, m.snapshotErr = msg.changes, msg.snapshotErr
+			applySummaries(m.watchlist, m.summaries)
+			m.applySearch()
+			if m.enriched || m.filter.needsSummaries() {
+				cmds = append(cmds, m.startEnrichment())
 			}
+		}
 
-			t := table.New(
-				table.WithColumns(columns),
-				table.WithRows(rows),
-				table.WithFocused(true),
-				table.WithHeight(15),
-			)
-
-			s := table.DefaultStyles()
-			s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
-			s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
-			t.SetStyles(s)
+	case filmSummaryMsg:
+		if msg.run != m.enrich.run || !m.enrich.running {
+			return m, nil
+		}
+		m.enrich.done++
+		if msg.err != nil {
+			m.enrich.failed++
+		} else {
+			m.summaries[msg.slug] = msg.summary
+			applySummaries(m.watchlist, m.summaries)
+			m.applySearch()
+		}
+		return m, m.enrich.wait()
 
-			m.table = t
+	case enrichDoneMsg:
+		if msg.run == m.enrich.run {
+			m.enrich.running = false
 		}
+		return m, nil
 
 	case exportResultMsg:
-		m.exportErr = msg.err
-		m.exportPath = msg.filePath
-		m.lastExportMsg = time.Now()
+		m.exportStatus = newExportStatus(msg)
 
 	case tea.WindowSizeMsg:
-		if m.showTable {
-			m.table.SetWidth(msg.Width - 4)
-		}
-		m.exportInput.Width = msg.Width - 20
+		m.width = msg.Width
+		m.table.SetWidth(msg.Width - 4)
+		m.exporter.input.Width = msg.Width - 20
 	}
 
 	if m.showSpinner {
@@ -359,12 +696,8 @@ func (m WatchlistModel) View() string {
 		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
 	}
 
-	if m.promptingExportPath {
-		return lipgloss.JoinVertical(lipgloss.Left,
-			fmt.Sprintf("Exporting watchlist for: %s", m.targetUser),
-			exportInputStyle.Render(m.exportInput.View()),
-			"\n(Enter path relative to current dir. Press Enter to confirm, Esc to cancel)",
-		)
+	if m.exporter.active {
+		return m.exporter.View(fmt.Sprintf("Exporting watchlist for: %s", m.targetUser))
 	}
 
 	if m.showSpinner {
@@ -372,17 +705,42 @@ func (m WatchlistModel) View() string {
 	}
 
 	if m.showTable {
-		exportMsg := ""
-		if time.Since(m.lastExportMsg) < 5*time.Second {
-			if m.exportErr != nil {
-				exportMsg = exportStatusStyle.Render(fmt.Sprintf("Export failed: %v", m.exportErr))
-			} else if m.exportPath != "" {
-				exportMsg = exportStatusStyle.Render(fmt.Sprintf("Watchlist exported to %s", m.exportPath))
-			}
+		headerLines := []string{
+			filmsTitleStyle.Render(fmt.Sprintf("@%s's watchlist", m.targetUser)),
+			filmsFilterStyle.Render(m.filter.String()),
+		}
+		if m.changes != nil {
+			headerLines = append(headerLines, listFollowStyle.Render(m.changes.summary()))
+		}
+		if m.snapshotErr != nil {
+			headerLines = append(headerLines, fmt.Sprintf("Could not save a snapshot: %v", m.snapshotErr))
+		}
+		header := lipgloss.JoinVertical(lipgloss.Left, headerLines...)
+		help := "(Enter to view film, '/' to search, 's' sort, 'd' decade, 'g' genre, 't' runtime, 'i' fetch details, 'p' pick for me, 'c' changes, 'v' start a poll, 'e' to export, Esc to go back)"
+
+		status := fmt.Sprintf("%d films", len(m.watchlist))
+		if len(m.filtered) < len(m.watchlist) {
+			status = fmt.Sprintf("%d of %d films", len(m.filtered), len(m.watchlist))
+		}
+		if query := m.searchInput.Value(); query != "" {
+			status = fmt.Sprintf("%d of %d films match '%s'", len(m.visible), len(m.filtered), query)
+		}
+		if len(m.filtered) == 0 && !m.enrich.running {
+			return lipgloss.JoinVertical(lipgloss.Left, header, "No films match these filters.", status, help)
+		}
+		blocks := []string{header}
+		if m.searching || m.searchInput.Value() != "" {
+			blocks = append(blocks, m.searchInput.View())
+		}
+		if m.enrich.running {
+			status += "  " + m.enrich.View(m.progressWidth())
+		} else if m.enrich.failed > 0 {
+			status += fmt.Sprintf("  (details unavailable for %d films)", m.enrich.failed)
 		}
+		blocks = append(blocks, m.baseStyle.Render(m.table.View()), status, help)
 
-		view := m.baseStyle.Render(m.table.View()) + "\n(Use ↑/↓ to scroll, 'e' to export, Esc to go back)"
-		if exportMsg != "" {
+		view := lipgloss.JoinVertical(lipgloss.Left, blocks...)
+		if exportMsg := m.exportStatus.View("Watchlist"); exportMsg != "" {
 			view += "\n" + exportMsg
 		}
 		return view
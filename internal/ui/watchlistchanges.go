@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var watchlistRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E57373"))
+
+type watchlistSnapshotsMsg struct {
+	times []time.Time
+	err   error
+}
+
+// WatchlistChangesModel shows the films added to and removed from a
+// watchlist between any two of its snapshots, the latest two to begin
+// with.
+type WatchlistChangesModel struct {
+	username  string
+	times     []time.Time
+	from      int
+	to        int
+	diff      WatchlistDiff
+	table     table.Model
+	baseStyle lipgloss.Style
+	loaded    bool
+	quitting  bool
+	err       error
+	back      tea.Model
+}
+
+func NewWatchlistChangesModel(username string, back tea.Model) WatchlistChangesModel {
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "", Width: 2},
+			{Title: "Title", Width: 40},
+			{Title: "Year", Width: 6},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("#00A86B"))
+	t.SetStyles(s)
+
+	return WatchlistChangesModel{
+		username:  username,
+		table:     t,
+		baseStyle: lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")),
+		back:      back,
+	}
+}
+
+func (m WatchlistChangesModel) Init() tea.Cmd {
+	username := m.username
+	return func() tea.Msg {
+		times, err := watchlistSnapshotTimes(username)
+		return watchlistSnapshotsMsg{times: times, err: err}
+	}
+}
+
+func (m WatchlistChangesModel) goBack() (tea.Model, tea.Cmd) {
+	if m.back != nil {
+		return m.back, nil
+	}
+	return NewMenuModel(), nil
+}
+
+// compare diffs the two selected snapshots and fills the table, added
+// films first.
+func (m *WatchlistChangesModel) compare() {
+	d, err := diffWatchlistSnapshots(m.username, m.times[m.from], m.times[m.to])
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.diff = d
+
+	var rows []table.Row
+	for _, f := range d.Added {
+		rows = append(rows, table.Row{"+", f.Title, fmt.Sprintf("%d", f.Year)})
+	}
+	for _, f := range d.Removed {
+		rows = append(rows, table.Row{"−", f.Title, fmt.Sprintf("%d", f.Year)})
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(0)
+}
+
+// selected returns the film under the cursor.
+func (m WatchlistChangesModel) selected() (Movie, bool) {
+	i := m.table.Cursor()
+	if i < len(m.diff.Added) {
+		return m.diff.Added[i], true
+	}
+	i -= len(m.diff.Added)
+	if i >= 0 && i < len(m.diff.Removed) {
+		return m.diff.Removed[i], true
+	}
+	return Movie{}, false
+}
+
+func (m WatchlistChangesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			return m.goBack()
+
+		case "left", "right", "[", "]":
+			if len(m.times) < 2 {
+				return m, nil
+			}
+			switch msg.String() {
+			case "left":
+				if m.from > 0 {
+					m.from--
+				}
+			case "right":
+				if m.from < m.to-1 {
+					m.from++
+				}
+			case "[":
+				if m.to > m.from+1 {
+					m.to--
+				}
+			case "]":
+				if m.to < len(m.times)-1 {
+					m.to++
+				}
+			}
+			m.compare()
+			return m, nil
+
+		case "enter":
+			if film, ok := m.selected(); ok {
+				return newFilmDetailsModel(film, m)
+			}
+			return m, nil
+		}
+
+	case watchlistSnapshotsMsg:
+		m.loaded = true
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.times = msg.times
+		if len(m.times) >= 2 {
+			m.to = len(m.times) - 1
+			m.from = m.to - 1
+			m.compare()
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.table.SetWidth(msg.Width - 4)
+	}
+
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m WatchlistChangesModel) View() string {
+	if m.quitting {
+		return "Goodbye!"
+	}
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\n(Press 'esc' to go back)", m.err)
+	}
+	if !m.loaded {
+		return "\n\n   Loading snapshots...\n\n"
+	}
+
+	header := filmsTitleStyle.Render(fmt.Sprintf("Changes to @%s's watchlist", m.username))
+	if len(m.times) < 2 {
+		note := "Each time the full watchlist is fetched a snapshot is saved. Changes show up once there are two."
+		if len(m.times) == 1 {
+			note = fmt.Sprintf("Only one snapshot so far (%s). Changes show up once the watchlist has been fetched again.",
+				m.times[0].Local().Format("2 Jan 2006 15:04"))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, header, note, "", "(Esc to go back)")
+	}
+
+	span := filmsFilterStyle.Render(fmt.Sprintf("%s → %s  (snapshots %d and %d of %d)",
+		m.diff.From.Local().Format("2 Jan 2006 15:04"), m.diff.To.Local().Format("2 Jan 2006 15:04"),
+		m.from+1, m.to+1, len(m.times)))
+	summary := fmt.Sprintf("%s, %s",
+		listFollowStyle.Render(fmt.Sprintf("%d added", len(m.diff.Added))),
+		watchlistRemovedStyle.Render(fmt.Sprintf("%d removed", len(m.diff.Removed))))
+	help := "(Enter to view film, ←/→ earlier or later start, [/] earlier or later end, Esc to go back)"
+
+	if len(m.diff.Added)+len(m.diff.Removed) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, span, "No changes between these snapshots.", "", help)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, span, m.baseStyle.Render(m.table.View()), summary, help)
+}
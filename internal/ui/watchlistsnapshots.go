@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshonweb/letterbox-cli/internal/store"
+)
+
+// snapshotTimeFormat names snapshots so they sort by time and make valid
+// file names everywhere.
+const snapshotTimeFormat = "20060102T150405Z"
+
+// watchlistSnapshot is a user's watchlist as it was at one moment.
+type watchlistSnapshot struct {
+	Username string    `json:"username"`
+	TakenAt  time.Time `json:"taken_at"`
+	Films    []Movie   `json:"films"`
+}
+
+// WatchlistDiff is how a watchlist changed between two snapshots.
+type WatchlistDiff struct {
+	Username string
+	From     time.Time
+	To       time.Time
+	Added    []Movie
+	Removed  []Movie
+}
+
+func watchlistStore() (*store.Store, error) {
+	return store.Open("watchlists")
+}
+
+func snapshotPrefix(username string) string {
+	return strings.ToLower(username) + "/"
+}
+
+// watchlistSnapshotTimes returns when each of username's snapshots was
+// taken, oldest first.
+func watchlistSnapshotTimes(username string) ([]time.Time, error) {
+	s, err := watchlistStore()
+	if err != nil {
+		return nil, err
+	}
+	return snapshotTimes(s, username)
+}
+
+// snapshotTimes returns when each of username's snapshots in s was taken,
+// oldest first.
+func snapshotTimes(s *store.Store, username string) ([]time.Time, error) {
+	keys, err := s.Keys()
+	if err != nil {
+		return nil, err
+	}
+	var times []time.Time
+	for _, key := range keys {
+		stamp, ok := strings.CutPrefix(key, snapshotPrefix(username))
+		if !ok {
+			continue
+		}
+		if t, err := time.Parse(snapshotTimeFormat, stamp); err == nil {
+			times = append(times, t)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+func loadWatchlistSnapshot(username string, at time.Time) (watchlistSnapshot, error) {
+	var snap watchlistSnapshot
+	s, err := watchlistStore()
+	if err != nil {
+		return snap, err
+	}
+	found, err := s.Load(snapshotPrefix(username)+at.UTC().Format(snapshotTimeFormat), &snap)
+	if err == nil && !found {
+		err = fmt.Errorf("no snapshot of %s's watchlist from %s", username, at.Local().Format("2 Jan 2006 15:04"))
+	}
+	return snap, err
+}
+
+// saveWatchlistSnapshot records the films on username's watchlist now and
+// returns how they differ from the latest earlier snapshot, or nil if
+// this is the first. Nothing is saved when the films are the same as in
+// that snapshot, so fetching repeatedly does not bury real changes under
+// identical snapshots.
+func saveWatchlistSnapshot(username string, films []WatchlistFilm) (*listChanges, error) {
+	movies := make([]Movie, len(films))
+	for i, f := range films {
+		movies[i] = Movie{Title: f.Title, Year: f.Year, Slug: f.Slug}
+	}
+
+	times, err := watchlistSnapshotTimes(username)
+	if err != nil {
+		return nil, err
+	}
+	var changes *listChanges
+	if len(times) > 0 {
+		previous, err := loadWatchlistSnapshot(username, times[len(times)-1])
+		if err != nil {
+			return nil, err
+		}
+		changes = &listChanges{Since: previous.TakenAt}
+		changes.Added, changes.Removed = diffFilms(previous.Films, movies)
+		if len(changes.Added) == 0 && len(changes.Removed) == 0 {
+			return changes, nil
+		}
+	}
+
+	s, err := watchlistStore()
+	if err != nil {
+		return changes, err
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	snap := watchlistSnapshot{Username: username, TakenAt: now, Films: movies}
+	return changes, s.Save(snapshotPrefix(username)+now.Format(snapshotTimeFormat), snap)
+}
+
+// snapshotAtOrBefore returns the latest of times not after t.
+func snapshotAtOrBefore(times []time.Time, t time.Time) (time.Time, bool) {
+	for i := len(times) - 1; i >= 0; i-- {
+		if !times[i].After(t) {
+			return times[i], true
+		}
+	}
+	return time.Time{}, false
+}
+
+// diffWatchlistSnapshots compares the snapshots of username's watchlist
+// taken at from and to.
+func diffWatchlistSnapshots(username string, from, to time.Time) (WatchlistDiff, error) {
+	before, err := loadWatchlistSnapshot(username, from)
+	if err != nil {
+		return WatchlistDiff{}, err
+	}
+	after, err := loadWatchlistSnapshot(username, to)
+	if err != nil {
+		return WatchlistDiff{}, err
+	}
+	d := WatchlistDiff{Username: username, From: before.TakenAt, To: after.TakenAt}
+	d.Added, d.Removed = diffFilms(before.Films, after.Films)
+	return d, nil
+}
+
+// DiffWatchlist reports how username's watchlist changed between the
+// snapshots in force at from and to, i.e. the latest taken on or before
+// each. A zero to fetches the watchlist now, snapshotting it first; a
+// zero from means the snapshot before to.
+func DiffWatchlist(username string, from, to time.Time) (WatchlistDiff, error) {
+	if to.IsZero() {
+		films, err := callPythonGetWatchlist(username)
+		if err != nil {
+			return WatchlistDiff{}, err
+		}
+		changes, err := saveWatchlistSnapshot(username, films)
+		if err != nil {
+			return WatchlistDiff{}, err
+		}
+		to = time.Now()
+		// changes already compares this fetch with the latest snapshot,
+		// which is all the default range asks for, and also covers an
+		// unchanged watchlist that was not snapshotted again.
+		if from.IsZero() && changes != nil {
+			return WatchlistDiff{Username: username, From: changes.Since, To: to, Added: changes.Added, Removed: changes.Removed}, nil
+		}
+	}
+
+	times, err := watchlistSnapshotTimes(username)
+	if err != nil {
+		return WatchlistDiff{}, err
+	}
+	toSnap, ok := snapshotAtOrBefore(times, to)
+	if !ok {
+		return WatchlistDiff{}, fmt.Errorf("no snapshot of %s's watchlist on or before %s", username, to.Format("2 Jan 2006"))
+	}
+
+	var fromSnap time.Time
+	if from.IsZero() {
+		fromSnap, ok = snapshotAtOrBefore(times, toSnap.Add(-time.Second))
+		if !ok {
+			return WatchlistDiff{}, fmt.Errorf("only one snapshot of %s's watchlist so far; changes show up once it has been fetched again", username)
+		}
+	} else {
+		fromSnap, ok = snapshotAtOrBefore(times, from)
+		if !ok {
+			return WatchlistDiff{}, fmt.Errorf("no snapshot of %s's watchlist on or before %s; the first is from %s", username, from.Format("2 Jan 2006"), times[0].Local().Format("2 Jan 2006"))
+		}
+	}
+	return diffWatchlistSnapshots(username, fromSnap, toSnap)
+}
@@ -0,0 +1,50 @@
+#!/usr/bin/env python3
+import sys
+import json
+
+from letterboxdpy.movie import Movie
+
+from get_movie_details import get_watch_providers
+
+
+def get_film_summary(slug, providers=False):
+    """
+    Fetches the few details the watchlist shows for each film: director,
+    runtime in minutes, average rating and genres. With providers, it also
+    lists the services streaming the film, which takes two more requests.
+    """
+    try:
+        movie = Movie(slug)
+    except Exception as e:
+        return {"error": f"Failed to fetch film '{slug}': {e}"}
+
+    crew = getattr(movie, "crew", None) or {}
+    directors = [d.get("name", "") for d in crew.get("director", []) if d.get("name")]
+    runtime = getattr(movie, "runtime", None)
+    rating = getattr(movie, "rating", None)
+    genres = getattr(movie, "genres", None) or []
+
+    summary = {
+        "slug": slug,
+        "director": ", ".join(directors),
+        # Unknown runtimes are null rather than 0, which would read as short.
+        "runtime": runtime if isinstance(runtime, int) and runtime > 0 else None,
+        "rating": float(rating) if isinstance(rating, (int, float)) else 0.0,
+        "genres": [g.get("name", "") for g in genres if g.get("type") == "genre"],
+    }
+    if providers:
+        summary["streaming"] = [p["name"] for p in get_watch_providers(slug) if p["type"] == "stream"]
+    return summary
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "Usage: get_film_summary.py <slug> [providers]"}))
+        sys.exit(1)
+
+    summary = get_film_summary(sys.argv[1], len(sys.argv) > 2 and sys.argv[2] == "providers")
+    if "error" in summary:
+        print(json.dumps(summary))
+        sys.exit(1)
+
+    print(json.dumps(summary, indent=4))
@@ -0,0 +1,88 @@
+#!/usr/bin/env python3
+import sys
+import json
+import re
+
+import requests
+from bs4 import BeautifulSoup
+
+from poster_grid import parse_poster
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+MAX_PAGES = 20
+
+
+def parse_rating(value):
+    try:
+        return round(float(value), 2)
+    except (TypeError, ValueError):
+        return 0.0
+
+
+def parse_film(container):
+    film = parse_poster(container)
+    if film is None:
+        return None
+
+    poster = container.select_one("[data-average-rating]")
+    rating = container.get("data-average-rating") or (poster.get("data-average-rating") if poster else None)
+    film["rating"] = parse_rating(rating)
+    return film
+
+
+def get_filmography(role, slug):
+    """
+    Fetches every film listed on a person's role page, e.g.
+    https://letterboxd.com/director/agnes-varda/.
+    """
+    base_url = f"https://letterboxd.com/{role}/{slug}/"
+    name = ""
+    films = []
+    seen = set()
+
+    for page in range(1, MAX_PAGES + 1):
+        url = base_url if page == 1 else f"{base_url}page/{page}/"
+        try:
+            res = requests.get(url, headers=HEADERS, timeout=10)
+            if res.status_code == 404 and page > 1:
+                break
+            res.raise_for_status()
+        except requests.RequestException as e:
+            if page == 1:
+                return {"error": f"Failed to fetch filmography for '{slug}': {e}"}
+            break
+
+        soup = BeautifulSoup(res.text, "html.parser")
+        if not name:
+            og_title = soup.select_one('meta[property="og:title"]')
+            if og_title and og_title.get("content"):
+                name = re.sub(r"^Films (starring|directed by|written by|with music composed by|.* by)\s+", "",
+                              og_title["content"], flags=re.IGNORECASE).strip()
+
+        containers = soup.select("li.poster-container, li.griditem, div.poster-grid li")
+        for container in containers:
+            film = parse_film(container)
+            if film and film["slug"] not in seen:
+                seen.add(film["slug"])
+                films.append(film)
+
+        if not soup.select_one("a.next"):
+            break
+
+    return {
+        "person": {"name": name or slug.replace("-", " ").title(), "slug": slug, "role": role},
+        "films": films,
+    }
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 3:
+        print(json.dumps({"error": "Usage: get_filmography.py <role> <person_slug>"}))
+        sys.exit(1)
+
+    result = get_filmography(sys.argv[1], sys.argv[2])
+    if "error" in result:
+        print(json.dumps(result))
+        sys.exit(1)
+
+    print(json.dumps(result, indent=4))
@@ -0,0 +1,88 @@
+#!/usr/bin/env python3
+import sys
+import json
+
+import requests
+from bs4 import BeautifulSoup
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+KINDS = ("following", "followers")
+
+
+def parse_person(row):
+    link = row.select_one("a.name, h3 a, .person-summary a[href]")
+    if link is None or not link.get("href"):
+        return None
+    parts = [p for p in link["href"].split("/") if p]
+    if len(parts) != 1:
+        return None
+    return {
+        "username": parts[0],
+        "name": link.get_text(strip=True) or parts[0],
+    }
+
+
+def get_follows_page(username, kind, page):
+    """Fetches one page of the people a user follows, or their followers."""
+    if kind not in KINDS:
+        return {"error": f"Unknown list '{kind}', expected one of: {', '.join(KINDS)}"}
+
+    url = f"https://letterboxd.com/{username}/{kind}/"
+    if page > 1:
+        url += f"page/{page}/"
+    try:
+        res = requests.get(url, headers=HEADERS, timeout=10)
+        if res.status_code == 404:
+            return {"error": f"User '{username}' not found"}
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"error": f"Failed to fetch {kind} for '{username}': {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    people = []
+    for row in soup.select("table.person-table tbody tr, .person-table .table-person"):
+        person = parse_person(row)
+        if person is not None:
+            people.append(person)
+    return {
+        "people": people,
+        "page": page,
+        "has_more": soup.select_one(".pagination a.next") is not None,
+    }
+
+
+def get_follows(username, kind):
+    """Fetches every page of the people a user follows, or their followers."""
+    people = []
+    seen = set()
+    page = 1
+    while True:
+        result = get_follows_page(username, kind, page)
+        if "error" in result:
+            return result
+        for person in result["people"]:
+            if person["username"] not in seen:
+                seen.add(person["username"])
+                people.append(person)
+        if not result["has_more"]:
+            break
+        page += 1
+
+    return people
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 3:
+        print(json.dumps({"error": "Usage: get_follows.py <username> <following|followers> [page]"}))
+        sys.exit(1)
+
+    if len(sys.argv) > 3:
+        people = get_follows_page(sys.argv[1], sys.argv[2], int(sys.argv[3]) if sys.argv[3].isdigit() else 1)
+    else:
+        people = get_follows(sys.argv[1], sys.argv[2])
+    if isinstance(people, dict) and "error" in people:
+        print(json.dumps(people))
+        sys.exit(1)
+
+    print(json.dumps(people, indent=4))
@@ -1,33 +1,154 @@
 import sys
 import json
+import re
+
+import requests
+from bs4 import BeautifulSoup
 from letterboxdpy.list import List
 
-def get_list_movies(owner_username, list_slug):
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+# The detail view shows each entry's rank and notes; it is paged this many
+# pages deep at most.
+MAX_PAGES = 100
+
+
+def parse_count(text, word):
+    match = re.search(r"([\d,.]+)\s*(k?)\s*" + word, text or "", re.IGNORECASE)
+    if not match:
+        return 0
+    value = float(match.group(1).replace(",", ""))
+    return int(value * 1000) if match.group(2) else int(value)
+
+
+def parse_header(soup, owner, slug):
+    title = soup.select_one("h1.title-1, .list-title-intro h1")
+    og_title = soup.select_one("meta[property='og:title']")
+    name = title.get_text(strip=True) if title else (og_title.get("content", "") if og_title else slug)
+
+    description = ""
+    body = soup.select_one(".list-title-intro .body-text, .list-description .body-text, section.list-header .body-text")
+    if body is not None:
+        description = "\n\n".join(p.get_text(" ", strip=True) for p in body.select("p")) or body.get_text(" ", strip=True)
+
+    tags = [a.get_text(strip=True) for a in soup.select("ul.tags li a")]
+
+    text = soup.get_text(" ", strip=True)
+    likes = 0
+    likes_link = soup.select_one(f"a[href*='/{owner}/list/{slug}/likes/']")
+    if likes_link is not None:
+        likes = parse_count(likes_link.get_text(" ", strip=True), "like")
+    comments_heading = soup.select_one("#comments h2, h2#comments-heading, .comments-heading")
+
+    return {
+        "name": name,
+        "owner": owner,
+        "slug": slug,
+        "description": description,
+        "tags": tags,
+        "likes": likes or parse_count(text, "likes?"),
+        "comments": parse_count(comments_heading.get_text(" ", strip=True) if comments_heading else text, "comments?"),
+    }
+
+
+def parse_entry(item):
+    poster = item.select_one("[data-film-slug], [data-item-slug], [data-target-link]")
+    if poster is None:
+        return None
+    slug = poster.get("data-film-slug") or poster.get("data-item-slug")
+    if not slug:
+        link = poster.get("data-target-link", "")
+        slug = link.rstrip("/").split("/")[-1] if "/film/" in link else ""
+    if not slug:
+        return None
+
+    heading = item.select_one("h2 a, .headline-2 a")
+    title = heading.get_text(strip=True) if heading else poster.get("data-film-name", slug)
+    year_link = item.select_one("small.metadata a, .metadata a")
+    year = year_link.get_text(strip=True) if year_link else poster.get("data-film-release-year", "")
+
+    rank = 0
+    number = item.select_one(".list-number")
+    if number is not None and number.get_text(strip=True).isdigit():
+        rank = int(number.get_text(strip=True))
+
+    notes = ""
+    body = item.select_one(".film-detail-content .body-text, .body-text")
+    if body is not None:
+        notes = "\n\n".join(p.get_text(" ", strip=True) for p in body.select("p")) or body.get_text(" ", strip=True)
+
+    return {
+        "title": title,
+        "year": int(year) if str(year).isdigit() else 0,
+        "slug": slug,
+        "director": "",
+        "rank": rank,
+        "notes": notes,
+    }
+
+
+def fetch(url):
+    res = requests.get(url, headers=HEADERS, timeout=10)
+    if res.status_code == 404:
+        return None
+    res.raise_for_status()
+    return BeautifulSoup(res.text, "html.parser")
+
+
+def get_list_entries(owner_username, list_slug):
+    """Falls back to letterboxdpy when the detail view cannot be read."""
+    list_instance = List(owner_username, list_slug)
+    entries = []
+    for movie_data in list_instance.movies.values():
+        url = movie_data.get('url', '')
+        entries.append({
+            "title": movie_data.get('name', 'Untitled'),
+            "year": movie_data.get('year', 0) or 0,
+            "slug": url.split('/film/')[-1].strip('/'),
+            "director": movie_data.get('director', '') or "",
+            "rank": 0,
+            "notes": "",
+        })
+    return entries
+
+
+def get_list_details(owner_username, list_slug):
     """
-    Fetches all movies from a specific Letterboxd list.
+    Fetches a Letterboxd list's description, tags and counts, and every
+    film on it with its rank and notes.
     """
+    base = f"https://letterboxd.com/{owner_username}/list/{list_slug}/"
     try:
-        list_instance = List(owner_username, list_slug)
-        movies = list_instance.movies
-    except Exception as e:
+        soup = fetch(base)
+        if soup is None:
+            return {"error": f"List '{owner_username}/{list_slug}' not found"}
+        details = parse_header(soup, owner_username, list_slug)
+
+        films = []
+        for page in range(1, MAX_PAGES + 1):
+            detail = fetch(base + "detail/" + (f"page/{page}/" if page > 1 else ""))
+            if detail is None:
+                break
+            for item in detail.select("li.film-detail, article.production-viewing"):
+                entry = parse_entry(item)
+                if entry is not None:
+                    films.append(entry)
+            if detail.select_one(".pagination a.next") is None:
+                break
+    except requests.RequestException as e:
         return {"error": f"Failed to fetch list '{owner_username}/{list_slug}': {e}"}
 
-    movies_out = []
-    for movie_data in movies.values():
+    if not films:
         try:
-            url = movie_data.get('url', '')
-            slug = url.split('/film/')[-1].strip('/')
-            
-            movies_out.append({
-                "title": movie_data.get('name', 'Untitled'),
-                "year": movie_data.get('year', 0),
-                "slug": slug,
-                "director": movie_data.get('director', 'N/A') 
-            })
-        except Exception:
-            continue
-            
-    return movies_out
+            films = get_list_entries(owner_username, list_slug)
+        except Exception as e:
+            return {"error": f"Failed to fetch list '{owner_username}/{list_slug}': {e}"}
+
+    details["ranked"] = any(f["rank"] for f in films)
+    details["films_count"] = len(films)
+    details["films"] = films
+    return details
+
 
 if __name__ == "__main__":
     if len(sys.argv) < 3:
@@ -36,5 +157,5 @@ if __name__ == "__main__":
 
     owner = sys.argv[1]
     slug = sys.argv[2]
-    details = get_list_movies(owner, slug)
+    details = get_list_details(owner, slug)
     print(json.dumps(details, indent=4))
@@ -84,6 +84,32 @@ def get_watch_providers(slug):
     return providers
 
 
+def get_rating_histogram(slug):
+    """
+    Returns the number of ratings at each half-star step, from ½ to ★★★★★.
+    Steps nobody picked are included with a count of zero.
+    """
+    url = f"https://letterboxd.com/csi/film/{slug}/rating-histogram/"
+    try:
+        res = requests.get(url, headers={"User-Agent": "Mozilla/5.0"}, timeout=10)
+        res.raise_for_status()
+    except requests.RequestException:
+        return []
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    histogram = []
+    for i, bar in enumerate(soup.select("li.rating-histogram-bar")[:10]):
+        count = 0
+        link = bar.select_one("a")
+        if link is not None:
+            label = link.get("data-original-title") or link.get("title") or link.get_text()
+            match = re.search(r"[\d,]+", label)
+            if match:
+                count = int(match.group(0).replace(",", ""))
+        histogram.append({"rating": (i + 1) / 2, "count": count})
+    return histogram
+
+
 def format_runtime(total_minutes):
     if not total_minutes or not isinstance(total_minutes, int) or total_minutes <= 0:
         return None
@@ -91,6 +117,98 @@ def format_runtime(total_minutes):
     return f"{hours}h {minutes}min"
 
 
+# Crew roles that have their own filmography pages on Letterboxd and are
+# worth offering from the details view.
+PERSON_CREW_ROLES = ("director", "writer", "composer")
+
+
+def person_slug_and_role(person, default_role):
+    """Derives the person slug and role page from a cast/crew entry."""
+    url = person.get("url", "")
+    parts = [p for p in url.split("/") if p and p not in ("https:", "letterboxd.com")]
+    role = parts[0] if len(parts) >= 2 else default_role
+    slug = person.get("slug") or (parts[1] if len(parts) >= 2 else "")
+    return slug, role
+
+
+def get_people(movie_instance):
+    people = []
+    crew = getattr(movie_instance, "crew", None) or {}
+    for role in PERSON_CREW_ROLES:
+        for person in crew.get(role, []):
+            slug, page_role = person_slug_and_role(person, role)
+            if slug:
+                people.append({"name": person.get("name", ""), "slug": slug, "role": page_role})
+    for actor in (getattr(movie_instance, "cast", None) or [])[:5]:
+        slug, page_role = person_slug_and_role(actor, "actor")
+        if slug:
+            people.append({"name": actor.get("name", ""), "slug": slug, "role": page_role})
+    return people
+
+
+# Letterboxd crew roles grouped into departments, in the order they are
+# shown. Roles not listed here end up under "Crew".
+CREW_DEPARTMENTS = [
+    ("Directing", ("director", "co-director", "assistant-director")),
+    ("Writing", ("writer", "original-writer", "story")),
+    ("Production", ("producer", "co-producer", "executive-producer", "casting")),
+    ("Camera", ("cinematography", "camera-operator", "additional-photography", "lighting")),
+    ("Editing", ("editor",)),
+    ("Sound", ("composer", "songs", "sound")),
+    ("Art", ("production-design", "art-direction", "set-decoration", "title-design")),
+    ("Costume & Make-Up", ("costume-design", "makeup", "hairstyling")),
+    ("Visual Effects", ("visual-effects", "special-effects")),
+]
+
+
+def get_credits(movie_instance):
+    """
+    Returns the full cast followed by the crew, one entry per person and
+    role. Cast entries carry the character name, crew entries the
+    department they belong to.
+    """
+    credits = []
+    for actor in getattr(movie_instance, "cast", None) or []:
+        slug, page_role = person_slug_and_role(actor, "actor")
+        credits.append({
+            "name": actor.get("name", ""),
+            "slug": slug,
+            "role": page_role,
+            "character": (actor.get("role_name") or "").strip(),
+        })
+
+    crew = getattr(movie_instance, "crew", None) or {}
+    known_roles = set()
+    for department, roles in CREW_DEPARTMENTS:
+        for role in roles:
+            known_roles.add(role)
+            for person in crew.get(role, []):
+                slug, page_role = person_slug_and_role(person, role)
+                credits.append({
+                    "name": person.get("name", ""),
+                    "slug": slug,
+                    "role": page_role,
+                    "department": department,
+                })
+    for role, people in crew.items():
+        if role in known_roles:
+            continue
+        for person in people:
+            slug, page_role = person_slug_and_role(person, role)
+            credits.append({
+                "name": person.get("name", ""),
+                "slug": slug,
+                "role": page_role,
+                "department": "Crew",
+            })
+    return credits
+
+
+def get_detail_names(movie_instance, detail_type):
+    details = getattr(movie_instance, "details", None) or []
+    return [d.get("name", "") for d in details if d.get("type") == detail_type]
+
+
 def get_movie_details(slug):
     try:
         movie_instance = Movie(slug)
@@ -111,6 +229,8 @@ def get_movie_details(slug):
             "rating": movie_instance.rating,
             "description": movie_instance.description,
             "url": movie_instance.url,
+            "poster": getattr(movie_instance, "poster", None) or "",
+            "still": getattr(movie_instance, "banner", None) or "",
             "reviews": [
                 {
                     "author": review['user']['username'],
@@ -122,6 +242,12 @@ def get_movie_details(slug):
             "providers": get_watch_providers(slug),
             "runtime": format_runtime(movie_instance.runtime),
             "cast" : [actor['name'] for actor in movie_instance.cast[:5]],
+            "people": get_people(movie_instance),
+            "credits": get_credits(movie_instance),
+            "studios": get_detail_names(movie_instance, "studio"),
+            "countries": get_detail_names(movie_instance, "country"),
+            "languages": get_detail_names(movie_instance, "language"),
+            "alternative_titles": getattr(movie_instance, "alternative_titles", None) or [],
         
             "release_date": movie_instance.details,
             
@@ -131,6 +257,7 @@ def get_movie_details(slug):
             "review_count":  movie_instance.get_watchers_stats()['reviews'],
             "lists":  movie_instance.get_watchers_stats()['lists'],
             "tagline": movie_instance.get_tagline(),
+            "histogram": get_rating_histogram(slug),
             "similar": similar_list
         }
     except Exception as e:
@@ -0,0 +1,158 @@
+#!/usr/bin/env python3
+import sys
+import json
+import re
+
+import requests
+from bs4 import BeautifulSoup
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+# Sort orders offered by the reviews tab and the Letterboxd path for each.
+SORTS = {
+    "popular": "reviews/",
+    "recent": "reviews/by/added/",
+    "earliest": "reviews/by/added-earliest/",
+    "highest": "reviews/by/entry-rating/",
+    "lowest": "reviews/by/entry-rating-lowest/",
+}
+
+
+def parse_rating(container):
+    """Converts a rated-N class (N out of 10) to a rating out of 5."""
+    rated = container.select_one("[class*='rated-']")
+    if rated is None:
+        return 0.0
+    for cls in rated.get("class", []):
+        match = re.match(r"^rated-(\d+)$", cls)
+        if match:
+            return int(match.group(1)) / 2.0
+    return 0.0
+
+
+def parse_int(value):
+    digits = re.sub(r"[^\d]", "", value or "")
+    return int(digits) if digits else 0
+
+
+def parse_likes(container):
+    target = container.select_one("[data-likes-count], [data-count]")
+    if target is not None:
+        return parse_int(target.get("data-likes-count") or target.get("data-count"))
+    link = container.select_one(".like-link-target, .likes-count, a[href$='/likes/']")
+    return parse_int(link.get_text()) if link else 0
+
+
+def parse_date(container):
+    time = container.select_one("time[datetime]")
+    if time is not None:
+        return time["datetime"][:10]
+    date = container.select_one("span.date, span._nobr")
+    return date.get_text(strip=True) if date else ""
+
+
+def body_text(body):
+    paragraphs = [p.get_text(" ", strip=True) for p in body.select("p")]
+    paragraphs = [p for p in paragraphs if p and "contains spoilers" not in p.lower()]
+    if paragraphs:
+        return "\n\n".join(paragraphs)
+    return body.get_text(" ", strip=True)
+
+
+def full_text(body):
+    """Fetches the full text of a review that Letterboxd truncated."""
+    url = body.get("data-full-text-url")
+    if not url:
+        return None
+    try:
+        res = requests.get(f"https://letterboxd.com{url}", headers=HEADERS, timeout=10)
+        res.raise_for_status()
+    except requests.RequestException:
+        return None
+    return body_text(BeautifulSoup(res.text, "html.parser"))
+
+
+def parse_review(container):
+    body = container.select_one(".body-text, .js-review-body")
+    if body is None:
+        return None
+
+    author = container.get("data-person") or container.get("data-owner") or ""
+    avatar = container.select_one("a.avatar, a.context")
+    if not author and avatar is not None:
+        author = avatar.get("href", "").strip("/").split("/")[0]
+    name = container.select_one("strong.displayname, strong.name, .displayname")
+
+    link = container.select_one("a.context, a[href*='/film/'][href*='/reviews/'], a[href*='/film/'].has-icon")
+    url = ""
+    if link is not None and link.get("href", "").startswith("/"):
+        url = f"https://letterboxd.com{link['href']}"
+
+    spoiler = (
+        container.select_one(".contains-spoilers") is not None
+        or "-hidden-spoilers" in body.get("class", [])
+        or "This review may contain spoilers" in container.get_text()
+    )
+
+    return {
+        "author": author,
+        "display_name": name.get_text(strip=True) if name else author,
+        "text": full_text(body) or body_text(body),
+        "rating": parse_rating(container),
+        "date": parse_date(container),
+        "likes": parse_likes(container),
+        "spoiler": spoiler,
+        "url": url,
+    }
+
+
+def get_movie_reviews(slug, sort, page):
+    if sort not in SORTS:
+        return {"error": f"Unknown sort '{sort}', expected one of: {', '.join(SORTS)}"}
+
+    url = f"https://letterboxd.com/film/{slug}/{SORTS[sort]}"
+    if page > 1:
+        url += f"page/{page}/"
+
+    try:
+        res = requests.get(url, headers=HEADERS, timeout=10)
+        if res.status_code == 404:
+            return {"error": f"Film '{slug}' not found"}
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"error": f"Could not fetch reviews: {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    reviews = []
+    for container in soup.select("article.production-viewing, li.film-detail, div.film-detail"):
+        review = parse_review(container)
+        if review is not None:
+            reviews.append(review)
+
+    return {
+        "reviews": reviews,
+        "sort": sort,
+        "page": page,
+        "has_more": soup.select_one(".pagination a.next") is not None,
+    }
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "Usage: get_movie_reviews.py <film_slug> [sort] [page]"}))
+        sys.exit(1)
+
+    slug = sys.argv[1]
+    sort = sys.argv[2] if len(sys.argv) > 2 else "popular"
+    try:
+        page = int(sys.argv[3]) if len(sys.argv) > 3 else 1
+    except ValueError:
+        print(json.dumps({"error": f"Invalid page number: {sys.argv[3]}"}))
+        sys.exit(1)
+
+    result = get_movie_reviews(slug, sort, max(page, 1))
+    if "error" in result:
+        print(json.dumps(result))
+        sys.exit(1)
+
+    print(json.dumps(result, indent=4))
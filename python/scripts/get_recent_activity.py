@@ -0,0 +1,164 @@
+#!/usr/bin/env python3
+import sys
+import json
+import re
+
+import requests
+from bs4 import BeautifulSoup
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+DIARY_DATE = re.compile(r"/diary/for/(\d{4})/(\d{2})/(\d{2})/")
+
+
+def fetch(url):
+    res = requests.get(url, headers=HEADERS, timeout=10)
+    if res.status_code == 404:
+        return None
+    res.raise_for_status()
+    return BeautifulSoup(res.text, "html.parser")
+
+
+def film_slug(container):
+    poster = container.select_one("[data-film-slug], [data-item-slug], [data-target-link]")
+    if poster is None:
+        return "", None
+    slug = poster.get("data-film-slug") or poster.get("data-item-slug")
+    if not slug:
+        link = poster.get("data-target-link", "")
+        slug = link.rstrip("/").split("/")[-1] if "/film/" in link else ""
+    return slug, poster
+
+
+def entry_date(container):
+    for link in container.select("a[href*='/diary/for/']"):
+        match = DIARY_DATE.search(link.get("href", ""))
+        if match:
+            return "-".join(match.groups())
+    stamp = container.select_one("time[datetime]")
+    if stamp is not None:
+        return stamp["datetime"][:10]
+    return ""
+
+
+def entry_rating(container):
+    field = container.select_one("input.rateit-field")
+    if field is not None and field.get("value", "").isdigit():
+        return int(field["value"]) / 2
+    stars = container.select_one("span.rating[class*='rated-']")
+    if stars is not None:
+        match = re.search(r"rated-(\d+)", " ".join(stars.get("class", [])))
+        if match:
+            return int(match.group(1)) / 2
+    return 0.0
+
+
+def body_text(container):
+    body = container.select_one(".body-text")
+    if body is None:
+        return ""
+    return "\n\n".join(p.get_text(" ", strip=True) for p in body.select("p")) or body.get_text(" ", strip=True)
+
+
+def parse_diary_row(row):
+    slug, poster = film_slug(row)
+    if not slug:
+        return None
+
+    heading = row.select_one("h3 a, h2 a, .headline-3 a")
+    title = heading.get_text(strip=True) if heading else (poster.get("data-film-name") or poster.get("data-item-name") or slug)
+    year = ""
+    released = row.select_one("td.td-released, td.col-releaseyear")
+    if released is not None:
+        year = released.get_text(strip=True)
+
+    rewatch = row.select_one("td.td-rewatch, td.col-rewatch")
+    review = row.select_one("td.td-review a[href], td.col-review a[href]")
+    return {
+        "kind": "diary",
+        "title": title,
+        "year": int(year) if year.isdigit() else 0,
+        "slug": slug,
+        "rating": entry_rating(row),
+        "date": entry_date(row),
+        "rewatch": rewatch is not None and "icon-status-off" not in rewatch.get("class", []),
+        "reviewed": review is not None and "icon-status-off" not in review.get("class", []),
+        "review": "",
+    }
+
+
+def parse_review(item):
+    slug, poster = film_slug(item)
+    if not slug:
+        return None
+    heading = item.select_one("h2 a, .headline-2 a")
+    year_link = item.select_one("small.metadata a, .metadata a")
+    year = year_link.get_text(strip=True) if year_link else ""
+    return {
+        "kind": "review",
+        "title": heading.get_text(strip=True) if heading else (poster.get("data-film-name") or slug),
+        "year": int(year) if year.isdigit() else 0,
+        "slug": slug,
+        "rating": entry_rating(item),
+        "date": entry_date(item),
+        "rewatch": False,
+        "review": body_text(item),
+    }
+
+
+def get_recent_activity(username):
+    """
+    Fetches the first page of a user's diary and of their reviews, and
+    returns them as one list of activity, newest first. Diary entries with
+    a review carry its text; rated entries without one are ratings.
+    """
+    base = f"https://letterboxd.com/{username}/films/"
+    try:
+        diary = fetch(base + "diary/")
+        if diary is None:
+            return {"error": f"User '{username}' not found"}
+        reviews = fetch(base + "reviews/")
+    except requests.RequestException as e:
+        return {"error": f"Failed to fetch activity for '{username}': {e}"}
+
+    written = {}
+    if reviews is not None:
+        for item in reviews.select("li.film-detail, article.production-viewing"):
+            review = parse_review(item)
+            if review is not None and review["review"]:
+                written.setdefault((review["slug"], review["date"]), review)
+
+    items = []
+    for row in diary.select("tr.diary-entry-row"):
+        entry = parse_diary_row(row)
+        if entry is None:
+            continue
+        review = written.pop((entry["slug"], entry["date"]), None)
+        if review is not None:
+            entry["kind"], entry["review"] = "review", review["review"]
+        elif entry["reviewed"]:
+            entry["kind"] = "review"
+        elif entry["rating"]:
+            entry["kind"] = "rating"
+        del entry["reviewed"]
+        items.append(entry)
+
+    # Reviews that are not on the first diary page, such as reviews of
+    # films without a diary date.
+    items.extend(r for r in written.values() if r["date"])
+
+    items.sort(key=lambda i: i["date"], reverse=True)
+    return items
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "Usage: get_recent_activity.py <username>"}))
+        sys.exit(1)
+
+    activity = get_recent_activity(sys.argv[1])
+    if isinstance(activity, dict) and "error" in activity:
+        print(json.dumps(activity))
+        sys.exit(1)
+
+    print(json.dumps(activity, indent=4))
@@ -0,0 +1,42 @@
+#!/usr/bin/env python3
+import sys
+import json
+
+import requests
+from bs4 import BeautifulSoup
+
+from poster_grid import GRID_ITEMS, parse_poster
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+
+def get_similar_films(slug):
+    """Fetches the films Letterboxd lists as similar to a film."""
+    try:
+        res = requests.get(f"https://letterboxd.com/film/{slug}/similar/", headers=HEADERS, timeout=10)
+        if res.status_code == 404:
+            return {"error": f"Film '{slug}' not found"}
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"error": f"Failed to fetch films similar to '{slug}': {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    films = []
+    for container in soup.select(GRID_ITEMS):
+        film = parse_poster(container)
+        if film is not None and film["slug"] != slug:
+            films.append(film)
+    return films
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "Usage: get_similar_films.py <slug>"}))
+        sys.exit(1)
+
+    films = get_similar_films(sys.argv[1])
+    if isinstance(films, dict) and "error" in films:
+        print(json.dumps(films))
+        sys.exit(1)
+
+    print(json.dumps(films, indent=4))
@@ -0,0 +1,78 @@
+#!/usr/bin/env python3
+import sys
+import json
+import re
+
+import requests
+from bs4 import BeautifulSoup
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+DIARY_DATE = re.compile(r"/diary/for/(\d{4})/(\d{2})/(\d{2})/")
+
+
+def parse_rating(soup):
+    stars = soup.select_one("#content span.rating[class*='rated-'], span.rating[class*='rated-']")
+    if stars is None:
+        return 0.0
+    match = re.search(r"rated-(\d+)", " ".join(stars.get("class", [])))
+    return int(match.group(1)) / 2 if match else 0.0
+
+
+def parse_date(soup):
+    for link in soup.select("a[href*='/diary/for/']"):
+        match = DIARY_DATE.search(link.get("href", ""))
+        if match:
+            return "-".join(match.groups())
+    stamp = soup.select_one("time[datetime]")
+    return stamp["datetime"][:10] if stamp is not None else ""
+
+
+def parse_review(soup):
+    body = soup.select_one(".review .body-text, .js-review-body, .body-text")
+    if body is None:
+        return ""
+    return "\n\n".join(p.get_text(" ", strip=True) for p in body.select("p")) or body.get_text(" ", strip=True)
+
+
+def get_user_film(session, username, slug):
+    """
+    Fetches how a user rated and reviewed a film. Users who have not
+    logged or rated it are returned with watched set to false.
+    """
+    url = f"https://letterboxd.com/{username}/film/{slug}/"
+    result = {"username": username, "watched": False, "rating": 0.0, "review": "", "date": "", "url": url}
+    try:
+        res = session.get(url, headers=HEADERS, timeout=10)
+        if res.status_code == 404:
+            return result
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"username": username, "error": f"Failed to fetch '{slug}' for '{username}': {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    result.update({
+        "watched": True,
+        "rating": parse_rating(soup),
+        "review": parse_review(soup),
+        "date": parse_date(soup),
+    })
+    return result
+
+
+def get_film_for_users(slug, usernames):
+    """
+    Looks a film up for several users in one run, in the order given. A
+    user who could not be looked up gets an error in their place rather
+    than failing the others.
+    """
+    with requests.Session() as session:
+        return [get_user_film(session, username, slug) for username in usernames]
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 3:
+        print(json.dumps({"error": "Usage: get_user_film.py <slug> <username> [username...]"}))
+        sys.exit(1)
+
+    print(json.dumps(get_film_for_users(sys.argv[1], sys.argv[2:]), indent=4))
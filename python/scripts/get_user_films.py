@@ -0,0 +1,109 @@
+#!/usr/bin/env python3
+import sys
+import json
+import re
+
+import requests
+from bs4 import BeautifulSoup
+
+from poster_grid import GRID_ITEMS, parse_poster
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+# Sort orders offered by the films screen and the Letterboxd path for each.
+SORTS = {
+    "rating": "by/entry-rating/",
+    "release": "by/release/",
+    "rated": "by/rated-date/",
+    "popularity": "by/popular/",
+}
+
+
+def parse_rating(container):
+    """Converts a rated-N class (N out of 10) to a rating out of 5."""
+    rated = container.select_one("[class*='rated-']")
+    if rated is None:
+        return 0.0
+    for cls in rated.get("class", []):
+        match = re.match(r"^rated-(\d+)$", cls)
+        if match:
+            return int(match.group(1)) / 2.0
+    return 0.0
+
+
+def parse_film(container):
+    film = parse_poster(container)
+    if film is None:
+        return None
+
+    viewing = container.select_one(".poster-viewingdata") or container
+    film.update({
+        "rating": parse_rating(viewing),
+        "liked": viewing.select_one(".like, .icon-liked") is not None,
+        "reviewed": viewing.select_one(".review-micro, a[href*='/review']") is not None,
+    })
+    return film
+
+
+def films_url(username, sort, decade, genre, rated, page):
+    url = f"https://letterboxd.com/{username}/films/"
+    if rated == "rated":
+        url += "rated/.5-5/"
+    elif rated == "unrated":
+        url += "rated/none/"
+    if decade:
+        url += f"decade/{decade}/"
+    if genre:
+        url += f"genre/{genre}/"
+    url += SORTS[sort]
+    if page > 1:
+        url += f"page/{page}/"
+    return url
+
+
+def get_user_films(username, sort, decade, genre, rated, page):
+    if sort not in SORTS:
+        return {"error": f"Unknown sort '{sort}', expected one of: {', '.join(SORTS)}"}
+
+    url = films_url(username, sort, decade, genre, rated, page)
+    try:
+        res = requests.get(url, headers=HEADERS, timeout=10)
+        if res.status_code == 404:
+            return {"error": f"User '{username}' not found"}
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"error": f"Could not fetch films: {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    films = []
+    for container in soup.select(GRID_ITEMS):
+        film = parse_film(container)
+        if film is not None:
+            films.append(film)
+
+    return {
+        "films": films,
+        "page": page,
+        "has_more": soup.select_one(".pagination a.next") is not None,
+    }
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "Usage: get_user_films.py <username> [sort] [page] [decade] [genre] [rated|unrated]"}))
+        sys.exit(1)
+
+    args = sys.argv[1:] + [""] * 6
+    username, sort = args[0], args[1] or "rated"
+    try:
+        page = int(args[2]) if args[2] else 1
+    except ValueError:
+        print(json.dumps({"error": f"Invalid page number: {args[2]}"}))
+        sys.exit(1)
+
+    result = get_user_films(username, sort, args[3], args[4], args[5], max(page, 1))
+    if "error" in result:
+        print(json.dumps(result))
+        sys.exit(1)
+
+    print(json.dumps(result, indent=4))
@@ -0,0 +1,92 @@
+#!/usr/bin/env python3
+import sys
+import json
+import re
+
+import requests
+from bs4 import BeautifulSoup
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+# Which of a user's lists to fetch and the Letterboxd path for each.
+KINDS = {
+    "lists": "lists/",
+    "liked": "likes/lists/",
+}
+
+
+def parse_list(container):
+    link = container.select_one("h2 a[href*='/list/'], h3 a[href*='/list/'], a.list-link[href*='/list/']")
+    if link is None:
+        return None
+
+    parts = link.get("href", "").strip("/").split("/")
+    if len(parts) < 3 or parts[1] != "list":
+        return None
+
+    name = link.get_text(strip=True)
+    if not name:
+        img = container.select_one("img")
+        name = img.get("alt", "") if img else parts[2]
+
+    films = 0
+    match = re.search(r"([\d,]+)\s*films?", container.get_text(" ", strip=True))
+    if match:
+        films = int(match.group(1).replace(",", ""))
+
+    return {
+        "name": name,
+        "owner": parts[0],
+        "slug": parts[2],
+        "films": films,
+    }
+
+
+def get_user_lists(username, kind, page):
+    if kind not in KINDS:
+        return {"error": f"Unknown kind '{kind}', expected one of: {', '.join(KINDS)}"}
+
+    url = f"https://letterboxd.com/{username}/{KINDS[kind]}"
+    if page > 1:
+        url += f"page/{page}/"
+    try:
+        res = requests.get(url, headers=HEADERS, timeout=10)
+        if res.status_code == 404:
+            return {"error": f"User '{username}' not found"}
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"error": f"Could not fetch lists: {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    lists = []
+    for container in soup.select("section.list, article.list-summary, div.list-set > section"):
+        entry = parse_list(container)
+        if entry is not None and entry not in lists:
+            lists.append(entry)
+
+    return {
+        "lists": lists,
+        "page": page,
+        "has_more": soup.select_one(".pagination a.next") is not None,
+    }
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "Usage: get_user_lists.py <username> [lists|liked] [page]"}))
+        sys.exit(1)
+
+    args = sys.argv[1:] + [""] * 3
+    username, kind = args[0], args[1] or "lists"
+    try:
+        page = int(args[2]) if args[2] else 1
+    except ValueError:
+        print(json.dumps({"error": f"Invalid page number: {args[2]}"}))
+        sys.exit(1)
+
+    result = get_user_lists(username, kind, max(page, 1))
+    if "error" in result:
+        print(json.dumps(result))
+        sys.exit(1)
+
+    print(json.dumps(result, indent=4))
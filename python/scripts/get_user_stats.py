@@ -0,0 +1,133 @@
+#!/usr/bin/env python3
+import sys
+import json
+from concurrent.futures import ThreadPoolExecutor
+
+from letterboxdpy.user import User
+from letterboxdpy.movie import Movie
+
+# Film pages are fetched for at most this many films, most recently logged
+# first; the remaining films only contribute their rating and year.
+DEFAULT_MAX_DETAILS = 300
+WORKERS = 8
+TOP_BILLED = 5
+
+
+def normalize_rating(value):
+    """Letterboxd ratings come as 1-10 half stars; returns stars out of 5."""
+    if not isinstance(value, (int, float)) or value <= 0:
+        return 0.0
+    return float(value) / 2.0 if value > 5 else float(value)
+
+
+def film_details(slug):
+    try:
+        movie = Movie(slug)
+    except Exception:
+        return None
+
+    try:
+        average = float(movie.rating or 0)
+    except (TypeError, ValueError):
+        average = 0.0
+
+    return {
+        "year": movie.year or 0,
+        "runtime": movie.runtime if isinstance(movie.runtime, int) else 0,
+        "average": average,
+        "genres": [g["name"] for g in (movie.genres or []) if g.get("type") == "genre"],
+        "directors": [d.get("name", "") for d in (movie.crew or {}).get("director", [])],
+        "cast": [a.get("name", "") for a in (movie.cast or [])[:TOP_BILLED]],
+        "countries": [d.get("name", "") for d in (movie.details or []) if d.get("type") == "country"],
+    }
+
+
+def get_diary(user_instance):
+    viewings = []
+    diary = user_instance.get_diary() or {}
+    for entry in (diary.get("entries") or {}).values():
+        date = entry.get("date") or {}
+        try:
+            watched = f"{int(date['year'])}-{int(date['month']):02d}-{int(date['day']):02d}"
+        except (KeyError, TypeError, ValueError):
+            continue
+        actions = entry.get("actions") or {}
+        viewings.append({
+            "slug": entry.get("slug", ""),
+            "date": watched,
+            "rating": normalize_rating(actions.get("rating")),
+            "rewatch": bool(actions.get("rewatched")),
+            "runtime": entry.get("runtime") if isinstance(entry.get("runtime"), int) else 0,
+        })
+    viewings.sort(key=lambda v: v["date"], reverse=True)
+    return viewings
+
+
+def get_user_stats(username, max_details):
+    try:
+        user_instance = User(username)
+    except Exception as e:
+        return {"error": f"Failed to fetch user '{username}'. Exception: {e}"}
+
+    try:
+        films_data = user_instance.get_films() or {}
+        diary = get_diary(user_instance)
+    except Exception as e:
+        return {"error": f"Failed to fetch films for '{username}'. Exception: {e}"}
+
+    films = {}
+    for slug, info in (films_data.get("movies") or {}).items():
+        films[slug] = {
+            "slug": slug,
+            "title": info.get("name", ""),
+            "year": info.get("year") or 0,
+            "rating": normalize_rating(info.get("rating")),
+            "liked": bool(info.get("liked")),
+            "detailed": False,
+        }
+
+    # Films logged most recently are the most relevant to the breakdown.
+    order = []
+    for viewing in diary:
+        if viewing["slug"] in films and viewing["slug"] not in order:
+            order.append(viewing["slug"])
+    order += [slug for slug in films if slug not in order]
+    order = order[:max_details]
+
+    with ThreadPoolExecutor(max_workers=WORKERS) as pool:
+        for slug, details in zip(order, pool.map(film_details, order)):
+            if details is None:
+                continue
+            film = films[slug]
+            # The year from the user's film list wins; the film page only
+            # fills it in when the list had none.
+            year = details.pop("year")
+            film.update(details)
+            film["year"] = film["year"] or year
+            film["detailed"] = True
+
+    return {
+        "username": username,
+        "films": list(films.values()),
+        "diary": diary,
+        "total": films_data.get("count", len(films)),
+    }
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "Usage: get_user_stats.py <username> [max_details]"}))
+        sys.exit(1)
+
+    try:
+        max_details = int(sys.argv[2]) if len(sys.argv) > 2 else DEFAULT_MAX_DETAILS
+    except ValueError:
+        print(json.dumps({"error": f"Invalid number of films: {sys.argv[2]}"}))
+        sys.exit(1)
+
+    result = get_user_stats(sys.argv[1], max_details)
+    if "error" in result:
+        print(json.dumps(result))
+        sys.exit(1)
+
+    print(json.dumps(result, indent=4))
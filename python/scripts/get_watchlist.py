@@ -1,127 +1,58 @@
 #!/usr/bin/env python3
-
-
 import sys
-
-
 import json
 
+import requests
+from bs4 import BeautifulSoup
 
-from letterboxdpy.user import User
+from poster_grid import GRID_ITEMS, parse_poster
 
+HEADERS = {"User-Agent": "Mozilla/5.0"}
 
 
+def watchlist_url(username, page):
+    url = f"https://letterboxd.com/{username}/watchlist/"
+    if page > 1:
+        url += f"page/{page}/"
+    return url
 
 
 def get_watchlist(username):
-
-
-    """Fetches the watchlist for a given Letterboxd username."""
-
-
-    try:
-
-
-        user_instance = User(username)
-
-
-        watchlist_data = user_instance.get_watchlist()
-
-
-
-
-
-        if not watchlist_data or not isinstance(watchlist_data, dict) or not watchlist_data.get('available'):
-
-
-            return []
-
-
-        movie_dict = watchlist_data.get('data', {})
-
-
-        if not movie_dict or not isinstance(movie_dict, dict):
-
-
-            return []
-
-
-
-
-
-        watchlist_movies = []
-
-
-        for movie_info in movie_dict.values():
-
-
-
-
-
-            watchlist_movies.append({
-
-
-                "title": movie_info.get('name', 'Untitled'),
-
-
-                "year": movie_info.get('year', 0),
-
-
-                "slug": movie_info.get('slug', '')
-
-
-            })
-
-
-
-
-
-        return watchlist_movies
-
-
-
-
-
-    except Exception as e:
-
-
-        return {"error": f"Failed to fetch watchlist for '{username}'. Exception: {e}"}
-
-
-
+    """
+    Fetches every page of a user's watchlist, most recently added first.
+    Sorting and filtering happen in the app, on the list fetched once.
+    """
+    films = []
+    page = 1
+    while True:
+        try:
+            res = requests.get(watchlist_url(username, page), headers=HEADERS, timeout=10)
+            if res.status_code == 404:
+                return {"error": f"User '{username}' not found"}
+            res.raise_for_status()
+        except requests.RequestException as e:
+            return {"error": f"Failed to fetch watchlist for '{username}': {e}"}
+
+        soup = BeautifulSoup(res.text, "html.parser")
+        for container in soup.select(GRID_ITEMS):
+            film = parse_poster(container)
+            if film is not None:
+                films.append(film)
+        if soup.select_one(".pagination a.next") is None:
+            break
+        page += 1
+
+    return films
 
 
 if __name__ == "__main__":
-
-
     if len(sys.argv) < 2:
-
-
-        print(json.dumps({"error": "No username provided"}))
-
-
+        print(json.dumps({"error": "Usage: get_watchlist.py <username>"}))
         sys.exit(1)
 
-
-
-
-
-    username = sys.argv[1]
-
-
-    watchlist = get_watchlist(username)
-
-
+    watchlist = get_watchlist(sys.argv[1])
     if isinstance(watchlist, dict) and "error" in watchlist:
+        print(json.dumps(watchlist))
+        sys.exit(1)
 
-
-         print(json.dumps(watchlist))
-
-
-         sys.exit(1) 
-
-
-
-
-
-    print(json.dumps(watchlist, indent=4))
\ No newline at end of file
+    print(json.dumps(watchlist, indent=4))
@@ -0,0 +1,41 @@
+"""
+Reads films from Letterboxd poster grids. Shared by the scripts rather
+than run on its own, so it is not built as an executable.
+"""
+import re
+
+GRID_ITEMS = "li.poster-container, li.griditem"
+
+
+def parse_poster(container):
+    """
+    Reads a film from a poster grid entry, returning its title, year and
+    slug, or None if the entry is not a film. Letterboxd has used both
+    data-film-* and data-item-* attributes for the same information.
+    """
+    poster = container.select_one("[data-film-slug], [data-item-slug], [data-target-link]")
+    if poster is None:
+        return None
+
+    slug = poster.get("data-film-slug") or poster.get("data-item-slug")
+    if not slug:
+        link = poster.get("data-target-link", "")
+        slug = link.rstrip("/").split("/")[-1] if "/film/" in link else ""
+    if not slug:
+        return None
+
+    name = poster.get("data-film-name") or poster.get("data-item-name")
+    if not name:
+        img = poster.select_one("img")
+        name = img.get("alt", "") if img else slug
+
+    year = poster.get("data-film-release-year") or poster.get("data-film-year")
+    match = re.match(r"^(.*) \((\d{4})\)$", name)
+    if match:
+        name, year = match.group(1), year or match.group(2)
+
+    return {
+        "title": name,
+        "year": int(year) if year and str(year).isdigit() else 0,
+        "slug": slug,
+    }
@@ -1,29 +1,91 @@
 #!/usr/bin/env python3
 import sys
 import json
-from letterboxdpy.search import Search
+from urllib.parse import quote
+
+import requests
+from bs4 import BeautifulSoup
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+PAGE_SIZE = 20
+
+
+def parse_result(result):
+    """Reads the title, year, slug and directors of one search result."""
+    link = result.select_one("h2 a[href*='/film/'], .film-title-wrapper a[href*='/film/']")
+    if link is None:
+        return None
+    parts = [p for p in link["href"].split("/") if p]
+    if len(parts) < 2 or parts[0] != "film":
+        return None
+
+    year = 0
+    year_link = result.select_one("small.metadata a, .film-title-wrapper small a")
+    if year_link is not None and year_link.get_text(strip=True).isdigit():
+        year = int(year_link.get_text(strip=True))
+
+    # Shorts, TV episodes and unreleased films often have no director.
+    directors = [a.get_text(strip=True) for a in result.select("p.film-metadata a[href^='/director/']")]
+    return {
+        "title": link.get_text(strip=True),
+        "year": year,
+        "slug": parts[1],
+        "director": ", ".join(d for d in directors if d),
+    }
+
+
+def search_movie(query, page=1):
+    """
+    Returns one page of film search results. Letterboxd serves 20 results
+    per page; `has_more` tells the caller whether another page may follow.
+    Only the requested page is fetched, so paging deep costs no more than
+    the first page.
+    """
+    url = f"https://letterboxd.com/s/search/films/{quote(query)}/"
+    if page > 1:
+        url += f"page/{page}/"
+    try:
+        res = requests.get(url, headers=HEADERS, timeout=10)
+        if res.status_code == 404:
+            return {"movies": [], "page": page, "has_more": False}
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"error": f"Failed to search for '{query}': {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    results = soup.select("ul.results li")
 
-def search_movie(query):
-    search_instance = Search(query, 'films')
-    search_data = search_instance.get_results(max=5) 
-    
     movies = []
-    for item in search_data.get('results', []): 
-        if 'name' in item and 'year' in item and 'slug' in item:
-            movies.append({
-                "title": item['name'],
-                "year": item['year'],
-                "slug": item['slug'],
-                "director": item['directors'][0]['name'],
-            })
+    for result in results:
+        movie = parse_result(result)
+        if movie is not None:
+            movies.append(movie)
+
+    next_link = soup.select_one(".pagination a.next")
+    return {
+        "movies": movies,
+        "page": page,
+        "has_more": next_link is not None or len(results) >= PAGE_SIZE,
+    }
 
-    return movies
 
 if __name__ == "__main__":
     if len(sys.argv) < 2:
-        print(json.dumps([]))
+        print(json.dumps({"movies": [], "page": 1, "has_more": False}))
         sys.exit(0)
 
     query = sys.argv[1]
-    movies = search_movie(query)
-    print(json.dumps(movies, indent=4)) 
\ No newline at end of file
+    page = 1
+    if len(sys.argv) > 2:
+        try:
+            page = max(1, int(sys.argv[2]))
+        except ValueError:
+            print(json.dumps({"error": f"Invalid page number: {sys.argv[2]}"}))
+            sys.exit(1)
+
+    result = search_movie(query, page)
+    if "error" in result:
+        print(json.dumps(result))
+        sys.exit(1)
+
+    print(json.dumps(result, indent=4))
@@ -0,0 +1,60 @@
+#!/usr/bin/env python3
+import sys
+import json
+from urllib.parse import quote
+
+import requests
+from bs4 import BeautifulSoup
+
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+
+def search_people(query):
+    """
+    Searches Letterboxd's cast & crew index. Each result links to a role page
+    such as /actor/<slug>/ or /director/<slug>/.
+    """
+    url = f"https://letterboxd.com/s/search/cast-crew/{quote(query)}/"
+    try:
+        res = requests.get(url, headers=HEADERS, timeout=10)
+        res.raise_for_status()
+    except requests.RequestException as e:
+        return {"error": f"Failed to search for people: {e}"}
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    people = []
+    seen = set()
+    for result in soup.select("ul.results li"):
+        link = result.select_one("h2 a, h3 a")
+        if not link or not link.get("href"):
+            continue
+        parts = [p for p in link["href"].split("/") if p]
+        if len(parts) < 2:
+            continue
+        role, slug = parts[0], parts[1]
+        if (role, slug) in seen:
+            continue
+        seen.add((role, slug))
+
+        known_for = result.select_one("p.film-metadata, p.text-micro, .film-list")
+        people.append({
+            "name": link.get_text(strip=True),
+            "slug": slug,
+            "role": role,
+            "known_for": known_for.get_text(" ", strip=True) if known_for else "",
+        })
+
+    return people
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 2:
+        print(json.dumps({"error": "No search query provided"}))
+        sys.exit(1)
+
+    people = search_people(sys.argv[1])
+    if isinstance(people, dict) and "error" in people:
+        print(json.dumps(people))
+        sys.exit(1)
+
+    print(json.dumps(people, indent=4))
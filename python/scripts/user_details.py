@@ -1,7 +1,39 @@
 import json
+import re
 import sys
+
+import requests
+from bs4 import BeautifulSoup
 from letterboxdpy.user import User
 
+HEADERS = {"User-Agent": "Mozilla/5.0"}
+
+
+def follow_counts(username):
+    """
+    Reads how many people a user follows and is followed by from their
+    profile. The lists themselves are fetched a page at a time by
+    get_follows.py, since large accounts span many pages.
+    """
+    counts = {"following": 0, "followers": 0}
+    try:
+        res = requests.get(f"https://letterboxd.com/{username}/", headers=HEADERS, timeout=10)
+        res.raise_for_status()
+    except requests.RequestException:
+        return counts
+
+    soup = BeautifulSoup(res.text, "html.parser")
+    for kind in counts:
+        link = soup.select_one(f".profile-statistic a[href$='/{kind}/'], a[href$='/{username}/{kind}/']")
+        if link is None:
+            continue
+        value = link.select_one(".value") or link
+        digits = re.sub(r"[^\d]", "", value.get_text())
+        if digits:
+            counts[kind] = int(digits)
+    return counts
+
+
 def user_details(username):
     try:
         user_instance = User(username)
@@ -23,18 +55,7 @@ def user_details(username):
         except Exception:
             pass
 
-        followers_list = []
-        try:
-            followers_list = [user_info.get('name', 'Unknown') for user_info in user_instance.get_followers().values()]
-        except Exception:
-            pass
-
-        following_list = []
-        try:
-            following_list = [user_info.get('name', 'Unknown') for user_info in user_instance.get_following().values()]
-        except Exception:
-            pass
-            
+        counts = follow_counts(user_instance.username)
 
         films_watched_count = 0
         try:
@@ -64,8 +85,8 @@ def user_details(username):
             "username": user_instance.username,
             "films_watched": films_watched_count,
             "bio": user_instance.bio,
-            "following": following_list,
-            "followers": followers_list,
+            "following_count": counts["following"],
+            "followers_count": counts["followers"],
             "favorites": [movie_info.get('name', 'Untitled') for movie_info in user_instance.favorites.values()],
             "last_watched": movie_name,
             "reviews": reviews_list,